@@ -0,0 +1,93 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// Income is the JSON representation of a ValidatorEpochIncome. Gwei values are numbers, wei values decimal
+// strings.
+type Income struct {
+	Validator *uint64 `json:"validator,omitempty"`
+	Epoch     *uint64 `json:"epoch,omitempty"`
+
+	AttestationSourceReward            uint64         `json:"attestation_source_reward"`
+	AttestationSourcePenalty           uint64         `json:"attestation_source_penalty"`
+	AttestationTargetReward            uint64         `json:"attestation_target_reward"`
+	AttestationTargetPenalty           uint64         `json:"attestation_target_penalty"`
+	AttestationHeadReward              uint64         `json:"attestation_head_reward"`
+	FinalityDelayPenalty               uint64         `json:"finality_delay_penalty"`
+	InactivityPenalty                  uint64         `json:"inactivity_penalty"`
+	ProposerSlashingInclusionReward    uint64         `json:"proposer_slashing_inclusion_reward"`
+	ProposerAttestationInclusionReward uint64         `json:"proposer_attestation_inclusion_reward"`
+	ProposerSyncInclusionReward        uint64         `json:"proposer_sync_inclusion_reward"`
+	SyncCommitteeReward                uint64         `json:"sync_committee_reward"`
+	SyncCommitteePenalty               uint64         `json:"sync_committee_penalty"`
+	SlashingReward                     uint64         `json:"slashing_reward"`
+	SlashingPenalty                    uint64         `json:"slashing_penalty"`
+	TotalClRewards                     int64          `json:"total_cl_rewards"`
+	TxFeeRewardWei                     string         `json:"tx_fee_reward_wei"`
+	TxFeeRewards                       []*TxFeeReward `json:"tx_fee_rewards,omitempty"`
+	ProposalsMissed                    uint64         `json:"proposals_missed"`
+	ProposalsMissedClReward            uint64         `json:"proposals_missed_cl_reward"`
+	ProposalsMissedElRewardWei         string         `json:"proposals_missed_el_reward_wei"`
+	AttestationSourceMissedReward      uint64         `json:"attestation_source_missed_reward"`
+	AttestationTargetMissedReward      uint64         `json:"attestation_target_missed_reward"`
+	AttestationHeadMissedReward        uint64         `json:"attestation_head_missed_reward"`
+	AttestationEfficiency              float64        `json:"attestation_efficiency"` // percent of the ideal attestation rewards
+	SyncCommitteeMissedSlots           uint64         `json:"sync_committee_missed_slots"`
+	SyncCommitteeMissedReward          uint64         `json:"sync_committee_missed_reward"`
+	WithdrawalsAmount                  uint64         `json:"withdrawals_amount"`
+	WithdrawalsCount                   uint64         `json:"withdrawals_count"`
+}
+
+type TxFeeReward struct {
+	Slot            uint64 `json:"slot"`
+	ExecBlockNumber uint64 `json:"exec_block_number"`
+	FeeRewardWei    string `json:"fee_reward_wei"`
+}
+
+func wei(b []byte) string {
+	return new(big.Int).SetBytes(b).String()
+}
+
+func newIncome(income *types.ValidatorEpochIncome) *Income {
+	i := &Income{
+		AttestationSourceReward:            income.AttestationSourceReward,
+		AttestationSourcePenalty:           income.AttestationSourcePenalty,
+		AttestationTargetReward:            income.AttestationTargetReward,
+		AttestationTargetPenalty:           income.AttestationTargetPenalty,
+		AttestationHeadReward:              income.AttestationHeadReward,
+		FinalityDelayPenalty:               income.FinalityDelayPenalty,
+		InactivityPenalty:                  income.InactivityPenalty,
+		ProposerSlashingInclusionReward:    income.ProposerSlashingInclusionReward,
+		ProposerAttestationInclusionReward: income.ProposerAttestationInclusionReward,
+		ProposerSyncInclusionReward:        income.ProposerSyncInclusionReward,
+		SyncCommitteeReward:                income.SyncCommitteeReward,
+		SyncCommitteePenalty:               income.SyncCommitteePenalty,
+		SlashingReward:                     income.SlashingReward,
+		SlashingPenalty:                    income.SlashingPenalty,
+		TotalClRewards:                     income.TotalClRewards(),
+		TxFeeRewardWei:                     wei(income.TxFeeRewardWei),
+		ProposalsMissed:                    income.ProposalsMissed,
+		ProposalsMissedClReward:            income.ProposalsMissedClReward,
+		ProposalsMissedElRewardWei:         wei(income.ProposalsMissedElRewardWei),
+		AttestationSourceMissedReward:      income.AttestationSourceMissedReward,
+		AttestationTargetMissedReward:      income.AttestationTargetMissedReward,
+		AttestationHeadMissedReward:        income.AttestationHeadMissedReward,
+		AttestationEfficiency:              income.AttestationEfficiency(),
+		SyncCommitteeMissedSlots:           income.SyncCommitteeMissedSlots,
+		SyncCommitteeMissedReward:          income.SyncCommitteeMissedReward,
+		WithdrawalsAmount:                  income.WithdrawalsAmount,
+		WithdrawalsCount:                   income.WithdrawalsCount,
+	}
+	for _, r := range income.TxFeeRewards {
+		i.TxFeeRewards = append(i.TxFeeRewards, &TxFeeReward{
+			Slot:            r.Slot,
+			ExecBlockNumber: r.ExecBlockNumber,
+			FeeRewardWei:    wei(r.FeeRewardWei),
+		})
+	}
+	return i
+}
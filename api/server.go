@@ -0,0 +1,303 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gobitfly/eth-rewards/store"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Server serves the income saved in a store over HTTP:
+//
+//	GET /rewards/epoch/{epoch}?validators=&offset=&limit=
+//	GET /rewards/validator/{index}?from=&to=&offset=&limit=
+//	GET /rewards/aggregate?from=&to=&validators=
+//
+// validators is a comma separated list of validator indices. If to is omitted it defaults to the last
+// complete epoch, an omitted from to the first epoch for the validator endpoint and to to otherwise.
+// Aggregates over all validators are limited to a short range of epochs.
+type Server struct {
+	store store.Store
+	log   logrus.FieldLogger
+	mux   *http.ServeMux
+}
+
+func NewServer(s store.Store, log logrus.FieldLogger) *Server {
+	srv := &Server{
+		store: s,
+		log:   log,
+		mux:   http.NewServeMux(),
+	}
+	srv.mux.HandleFunc("/rewards/epoch/", srv.handleEpoch)
+	srv.mux.HandleFunc("/rewards/validator/", srv.handleValidator)
+	srv.mux.HandleFunc("/rewards/aggregate", srv.handleAggregate)
+	return srv
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Page is a paginated list of incomes.
+type Page struct {
+	Data   []*Income `json:"data"`
+	Total  int       `json:"total"`
+	Offset int       `json:"offset"`
+	Limit  int       `json:"limit"`
+}
+
+// Aggregate is the income summed up over the epochs and validators.
+type Aggregate struct {
+	From       uint64  `json:"from"`
+	To         uint64  `json:"to"`
+	Epochs     int     `json:"epochs"`
+	Validators int     `json:"validators"`
+	Income     *Income `json:"income"`
+}
+
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, a ...interface{}) error {
+	return &requestError{status: http.StatusBadRequest, err: fmt.Errorf(format, a...)}
+}
+
+func (s *Server) handleEpoch(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, func() (interface{}, error) {
+		epoch, err := pathUint(r, "/rewards/epoch/")
+		if err != nil {
+			return nil, err
+		}
+		validators, err := validatorsParam(r)
+		if err != nil {
+			return nil, err
+		}
+
+		rewards, err := s.store.Epoch(epoch)
+		if err != nil {
+			return nil, err
+		}
+
+		indices := make([]uint64, 0, len(rewards))
+		for v := range rewards {
+			if validators == nil || validators[v] {
+				indices = append(indices, v)
+			}
+		}
+		sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+		return paginate(r, len(indices), func(i int) *Income {
+			v := indices[i]
+			income := newIncome(rewards[v])
+			income.Validator = &v
+			return income
+		})
+	})
+}
+
+func (s *Server) handleValidator(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, func() (interface{}, error) {
+		validator, err := pathUint(r, "/rewards/validator/")
+		if err != nil {
+			return nil, err
+		}
+		from, to, err := s.epochRange(r, true)
+		if err != nil {
+			return nil, err
+		}
+
+		rewards, err := s.store.ValidatorEpochs(validator, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		epochs := make([]uint64, 0, len(rewards))
+		for e := range rewards {
+			epochs = append(epochs, e)
+		}
+		sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+		return paginate(r, len(epochs), func(i int) *Income {
+			e := epochs[i]
+			income := newIncome(rewards[e])
+			income.Epoch = &e
+			return income
+		})
+	})
+}
+
+func (s *Server) handleAggregate(w http.ResponseWriter, r *http.Request) {
+	s.respond(w, r, func() (interface{}, error) {
+		from, to, err := s.epochRange(r, false)
+		if err != nil {
+			return nil, err
+		}
+		validators, err := validatorsParam(r)
+		if err != nil {
+			return nil, err
+		}
+
+		var indices []uint64
+		for v := range validators {
+			indices = append(indices, v)
+		}
+		aggregate, err := store.Aggregated(s.store, from, to, indices)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Aggregate{
+			From:       from,
+			To:         to,
+			Epochs:     aggregate.Epochs,
+			Validators: aggregate.Validators,
+			Income:     newIncome(aggregate.Income),
+		}, nil
+	})
+}
+
+// respond writes the result of handler as JSON.
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, handler func() (interface{}, error)) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	result, err := handler()
+	if err != nil {
+		status := http.StatusInternalServerError
+		var reqErr *requestError
+		switch {
+		case errors.As(err, &reqErr):
+			status = reqErr.status
+		case errors.Is(err, store.ErrEpochNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrRangeTooLarge):
+			status = http.StatusBadRequest
+		default:
+			s.log.Errorf("error serving %v: %v", r.URL, err)
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		s.log.Errorf("error writing response of %v: %v", r.URL, err)
+	}
+}
+
+func pathUint(r *http.Request, prefix string) (uint64, error) {
+	param := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	v, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, badRequest("invalid path parameter %q", param)
+	}
+	return v, nil
+}
+
+func queryUint(r *http.Request, name string, def uint64) (uint64, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, badRequest("invalid %s %q", name, param)
+	}
+	return v, nil
+}
+
+// validatorsParam parses the validators filter, nil if all validators are requested.
+func validatorsParam(r *http.Request) (map[uint64]bool, error) {
+	param := r.URL.Query().Get("validators")
+	if param == "" {
+		return nil, nil
+	}
+	validators := make(map[uint64]bool)
+	for _, v := range strings.Split(param, ",") {
+		index, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, badRequest("invalid validator index %q", v)
+		}
+		validators[index] = true
+	}
+	return validators, nil
+}
+
+// epochRange parses the from and to parameters. to defaults to the last complete epoch, from to the first
+// epoch if allEpochs is set and to to otherwise.
+func (s *Server) epochRange(r *http.Request, allEpochs bool) (uint64, uint64, error) {
+	last, found, err := s.store.LastCompleted()
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return 0, 0, store.ErrEpochNotFound
+	}
+	to, err := queryUint(r, "to", last)
+	if err != nil {
+		return 0, 0, err
+	}
+	defaultFrom := to
+	if allEpochs {
+		defaultFrom = 0
+	}
+	from, err := queryUint(r, "from", defaultFrom)
+	if err != nil {
+		return 0, 0, err
+	}
+	if to < from {
+		return 0, 0, badRequest("invalid epoch range %v - %v", from, to)
+	}
+	return from, to, nil
+}
+
+// paginate returns the page selected by the offset and limit parameters of the total items.
+func paginate(r *http.Request, total int, item func(i int) *Income) (*Page, error) {
+	offset, err := queryUint(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryUint(r, "limit", defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 || limit > maxLimit {
+		return nil, badRequest("limit must be between 1 and %d", maxLimit)
+	}
+	if offset > uint64(total) {
+		offset = uint64(total)
+	}
+
+	page := &Page{
+		Data:   []*Income{},
+		Total:  total,
+		Offset: int(offset),
+		Limit:  int(limit),
+	}
+	for i := offset; i < uint64(total) && i < offset+limit; i++ {
+		page.Data = append(page.Data, item(int(i)))
+	}
+	return page, nil
+}
@@ -0,0 +1,44 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gobitfly/eth-rewards/store"
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// Backfill computes the epochs from start to end (inclusive) and saves them to the store. It resumes after
+// the epochs already completed at the beginning of the range, so an interrupted backfill continues where it
+// stopped. Only finalized epochs are saved, the backfill fails at the first epoch that is not finalized or
+// could not be computed.
+func (r *Rewarder) Backfill(ctx context.Context, s store.Store, start, end uint64) error {
+	first, err := s.FirstIncomplete(start)
+	if err != nil {
+		return err
+	}
+	if first > end {
+		return nil
+	}
+	if first > start {
+		r.log.Infof("resuming backfill at epoch %v", first)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for res := range r.GetRewardsForEpochRange(ctx, first, end) {
+		if res.Err != nil {
+			return fmt.Errorf("error computing epoch %v: %w", res.Epoch, res.Err)
+		}
+		if !res.Finalized {
+			return fmt.Errorf("%w: epoch %v", types.ErrEpochNotFinalized, res.Epoch)
+		}
+		err := s.SaveEpoch(res.Epoch, res.Rewards)
+		if err != nil {
+			return fmt.Errorf("error saving epoch %v: %w", res.Epoch, err)
+		}
+		r.log.Infof("saved epoch %v: %v validators", res.Epoch, len(res.Rewards))
+	}
+	return ctx.Err()
+}
@@ -1,20 +1,33 @@
 package beacon
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gobitfly/eth-rewards/cache"
 	"github.com/gobitfly/eth-rewards/types"
 )
 
 type Client struct {
 	endpoint   string
 	httpClient *http.Client
+	cache      cache.Cache
+
+	// genesisRoot is the genesis validators root of the beacon node's network, set by the first
+	// cached request
+	genesisMux  sync.Mutex
+	genesisRoot string
 }
 
 func NewClient(endpoint string, timeout time.Duration) *Client {
@@ -27,11 +40,123 @@ func NewClient(endpoint string, timeout time.Duration) *Client {
 	}
 }
 
-func (c *Client) Balance(slot uint64, validator uint64) (uint64, error) {
+// NewClientWithCache creates a Client that caches the responses the beacon node reports as finalized and
+// not execution optimistic.
+func NewClientWithCache(endpoint string, timeout time.Duration, c cache.Cache) *Client {
+	client := NewClient(endpoint, timeout)
+	client.cache = c
+	return client
+}
+
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req, nil)
+}
+
+func (c *Client) post(ctx context.Context, url string, data []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, data)
+}
+
+// do executes the request, serving it from the cache if possible.
+func (c *Client) do(req *http.Request, body []byte) (*http.Response, error) {
+	if c.cache == nil {
+		return c.httpClient.Do(req)
+	}
+
+	genesisRoot, err := c.genesisValidatorsRoot(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	key := cacheKey(genesisRoot, req, body)
+	cached, found, err := c.cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(cached)),
+			Request:    req,
+		}, nil
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil || resp.StatusCode != 200 {
+		return resp, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	var status struct {
+		Finalized           bool `json:"finalized"`
+		ExecutionOptimistic bool `json:"execution_optimistic"`
+	}
+	if json.Unmarshal(data, &status) == nil && status.Finalized && !status.ExecutionOptimistic {
+		err = c.cache.Put(key, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// genesisValidatorsRoot returns the genesis validators root of the beacon node's network, it is only
+// requested once.
+func (c *Client) genesisValidatorsRoot(ctx context.Context) (string, error) {
+	c.genesisMux.Lock()
+	defer c.genesisMux.Unlock()
+	if c.genesisRoot != "" {
+		return c.genesisRoot, nil
+	}
+
+	r, err := c.Genesis(ctx)
+	if err != nil {
+		return "", err
+	}
+	if r.Data.GenesisValidatorsRoot == "" {
+		return "", fmt.Errorf("missing genesis validators root")
+	}
+	c.genesisRoot = r.Data.GenesisValidatorsRoot
+	return c.genesisRoot, nil
+}
+
+// cacheKey identifies a request of a network by its path, query and body, independent of the endpoint
+// it is sent to.
+func cacheKey(genesisRoot string, req *http.Request, body []byte) string {
+	key := "cl/" + genesisRoot + "/" + req.Method + req.URL.RequestURI()
+	if len(body) > 0 {
+		key += fmt.Sprintf("#%x", sha256.Sum256(body))
+	}
+	return key
+}
+
+// validatorsRequestBody encodes the validator indices or pubkeys for the body of a rewards request,
+// an empty list requests the data of all validators.
+func validatorsRequestBody(validators []string) ([]byte, error) {
+	if len(validators) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(validators)
+}
+
+func (c *Client) Balance(ctx context.Context, slot uint64, validator uint64) (uint64, error) {
 
 	url := fmt.Sprintf("%s/eth/v1/beacon/states/%d/validator_balances?id=%d", c.endpoint, slot, validator)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
 		return 0, err
@@ -53,11 +178,71 @@ func (c *Client) Balance(slot uint64, validator uint64) (uint64, error) {
 
 }
 
-func (c *Client) AttestationRewards(epoch uint64) (*types.AttestationRewardsApiResponse, error) {
+// Validators returns the validators with the given indices or pubkeys at the state. If no ids are
+// given, all validators are returned. Statuses optionally filters by validator status.
+func (c *Client) Validators(ctx context.Context, stateID string, ids []string, statuses []string) (*types.ValidatorsApiResponse, error) {
+	if len(ids) == 0 {
+		return c.validators(ctx, stateID, nil, statuses)
+	}
+
+	r := &types.ValidatorsApiResponse{}
+	for start := 0; start < len(ids); start += validatorsBatchSize {
+		end := start + validatorsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch, err := c.validators(ctx, stateID, ids[start:end], statuses)
+		if err != nil {
+			return nil, err
+		}
+		r.Data = append(r.Data, batch.Data...)
+		r.ExecutionOptimistic = r.ExecutionOptimistic || batch.ExecutionOptimistic
+	}
+	return r, nil
+}
+
+// validatorsBatchSize is the number of ids requested at once to stay within the URL length limits of the nodes
+const validatorsBatchSize = 64
+
+func (c *Client) validators(ctx context.Context, stateID string, ids []string, statuses []string) (*types.ValidatorsApiResponse, error) {
+	query := url.Values{}
+	if len(ids) > 0 {
+		query.Set("id", strings.Join(ids, ","))
+	}
+	if len(statuses) > 0 {
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	url := fmt.Sprintf("%s/eth/v1/beacon/states/%s/validators?%s", c.endpoint, stateID, query.Encode())
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.ValidatorsApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *Client) AttestationRewards(ctx context.Context, epoch uint64, validators []string) (*types.AttestationRewardsApiResponse, error) {
 	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/attestations/%d", c.endpoint, epoch)
-	data := []byte("[]") //request data for all validators
+	data, err := validatorsRequestBody(validators)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(data))
+	resp, err := c.post(ctx, url, data)
 
 	if err != nil {
 		return nil, err
@@ -78,11 +263,14 @@ func (c *Client) AttestationRewards(epoch uint64) (*types.AttestationRewardsApiR
 	return r, nil
 }
 
-func (c *Client) SyncCommitteeRewards(slot uint64) (*types.SyncCommitteeRewardsApiResponse, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/sync_committee/%d", c.endpoint, slot)
-	data := []byte("[]") //request data for all validators
+func (c *Client) SyncCommitteeRewards(ctx context.Context, blockID string, validators []string) (*types.SyncCommitteeRewardsApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/sync_committee/%s", c.endpoint, blockID)
+	data, err := validatorsRequestBody(validators)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(data))
+	resp, err := c.post(ctx, url, data)
 
 	if err != nil {
 		return nil, err
@@ -109,10 +297,34 @@ func (c *Client) SyncCommitteeRewards(slot uint64) (*types.SyncCommitteeRewardsA
 	return r, nil
 }
 
-func (c *Client) BlockRewards(slot uint64) (*types.BlockRewardsApiResponse, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/blocks/%d", c.endpoint, slot)
+func (c *Client) SyncCommittee(ctx context.Context, stateID string, epoch uint64) (*types.SyncCommitteeApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/states/%s/sync_committees?epoch=%d", c.endpoint, stateID, epoch)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.SyncCommitteeApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *Client) BlockRewards(ctx context.Context, blockID string) (*types.BlockRewardsApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/blocks/%s", c.endpoint, blockID)
+
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
 		return nil, err
@@ -136,10 +348,10 @@ func (c *Client) BlockRewards(slot uint64) (*types.BlockRewardsApiResponse, erro
 	return r, nil
 }
 
-func (c *Client) ProposerAssignments(epoch uint64) (*types.EpochProposerAssignmentsApiResponse, error) {
+func (c *Client) ProposerAssignments(ctx context.Context, epoch uint64) (*types.EpochProposerAssignmentsApiResponse, error) {
 	url := fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%d", c.endpoint, epoch)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
 		return nil, err
@@ -160,46 +372,198 @@ func (c *Client) ProposerAssignments(epoch uint64) (*types.EpochProposerAssignme
 	return r, nil
 }
 
-func (c *Client) ExecutionBlockNumber(slot uint64) (uint64, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/blocks/%d", c.endpoint, slot)
+func (c *Client) BlockHeader(ctx context.Context, blockID string) (*types.BlockHeaderApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/headers/%s", c.endpoint, blockID)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		if resp.StatusCode == 404 {
-			return 0, types.ErrBlockNotFound
+			return nil, types.ErrBlockNotFound
 		}
-		return 0, fmt.Errorf("http request error: %s", resp.Status)
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
 	}
 
-	type internal struct {
-		Data struct {
-			Message struct {
-				Body struct {
-					ExecutionPayload struct {
-						BlockNumber  string   `json:"block_number"`
-						Transactions []string `json:"transactions"`
-					} `json:"execution_payload"`
-				} `json:"body"`
-			} `json:"message"`
-		} `json:"data"`
+	r := &types.BlockHeaderApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *Client) Block(ctx context.Context, blockID string) (*types.BeaconBlockApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v2/beacon/blocks/%s", c.endpoint, blockID)
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, err
 	}
-	var r internal
+	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(&r)
+	if resp.StatusCode != 200 {
+		if resp.StatusCode == 404 {
+			return nil, types.ErrBlockNotFound
+		}
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.BeaconBlockApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
 
+func (c *Client) ExecutionBlockNumber(ctx context.Context, slot uint64) (uint64, error) {
+	block, err := c.Block(ctx, strconv.FormatUint(slot, 10))
 	if err != nil {
 		return 0, err
 	}
 
-	if r.Data.Message.Body.ExecutionPayload.BlockNumber == "" { // slot if pre merge
+	if block.Data.ExecutionPayload == nil { // slot if pre merge
 		return 0, types.ErrSlotPreMerge
 	}
 
-	return strconv.ParseUint(r.Data.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+	return block.Data.ExecutionPayload.BlockNumber, nil
+}
+
+func (c *Client) FinalityCheckpoints(ctx context.Context, stateID string) (*types.FinalityCheckpointsApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/states/%s/finality_checkpoints", c.endpoint, stateID)
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.FinalityCheckpointsApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Genesis returns the genesis of the beacon node's network. It is never served from the cache.
+func (c *Client) Genesis(ctx context.Context) (*types.GenesisApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/genesis", c.endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.GenesisApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *Client) Spec(ctx context.Context) (*types.SpecApiResponse, error) {
+	url := fmt.Sprintf("%s/eth/v1/config/spec", c.endpoint)
+
+	resp, err := c.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	r := &types.SpecApiResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(r)
+
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Events subscribes to the topics of the beacon node's event stream and calls handler for every event
+// until ctx is cancelled, the stream ends or handler returns an error.
+func (c *Client) Events(ctx context.Context, topics []string, handler func(*types.Event) error) error {
+	query := url.Values{}
+	for _, topic := range topics {
+		query.Add("topics", topic)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/eth/v1/events?%s", c.endpoint, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// the stream is long-lived, the timeout of the client does not apply
+	resp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("http request error: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	event := &types.Event{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if event.Topic != "" || len(event.Data) > 0 {
+				if err := handler(event); err != nil {
+					return err
+				}
+			}
+			event = &types.Event{}
+		case strings.HasPrefix(line, "event:"):
+			event.Topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if len(event.Data) > 0 {
+				event.Data = append(event.Data, '\n')
+			}
+			event.Data = append(event.Data, strings.TrimSpace(strings.TrimPrefix(line, "data:"))...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("event stream closed")
 }
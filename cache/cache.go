@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Cache stores immutable responses of the beacon and EL nodes. Only data that can no longer change, e.g.
+// because it belongs to a finalized block or is identified by its hash, must be put into the cache.
+type Cache interface {
+	Get(key string) ([]byte, bool, error)
+	Put(key string, value []byte) error
+}
+
+// LevelDB is a Cache persisted in a goleveldb database.
+type LevelDB struct {
+	db *leveldb.DB
+}
+
+// NewLevelDB opens the database at path, creating it if necessary.
+func NewLevelDB(path string) (*LevelDB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDB{db: db}, nil
+}
+
+func (c *LevelDB) Get(key string) ([]byte, bool, error) {
+	value, err := c.db.Get([]byte(key), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *LevelDB) Put(key string, value []byte) error {
+	return c.db.Put([]byte(key), value, nil)
+}
+
+func (c *LevelDB) Close() error {
+	return c.db.Close()
+}
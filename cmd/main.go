@@ -1,12 +1,31 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	ethrewards "github.com/gobitfly/eth-rewards"
+	"github.com/gobitfly/eth-rewards/api"
 	"github.com/gobitfly/eth-rewards/beacon"
+	"github.com/gobitfly/eth-rewards/cache"
+	"github.com/gobitfly/eth-rewards/elrewards"
+	"github.com/gobitfly/eth-rewards/exporter"
+	"github.com/gobitfly/eth-rewards/grpcapi"
+	"github.com/gobitfly/eth-rewards/store"
+	"github.com/gobitfly/eth-rewards/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -14,40 +33,204 @@ func main() {
 	elNode := flag.String("el-node", "http://localhost:8545", "EL Node API Endpoint")
 	// network := flag.String("network", "", "Config to use (can be mainnet, prater or sepolia")
 	epoch := flag.Uint64("epoch", 1, "Epoch to calculate rewards for")
+	endEpoch := flag.Uint64("end-epoch", 0, "Last epoch to calculate rewards for (default -epoch)")
+	validators := flag.String("validators", "", "Comma separated list of validator indices or pubkeys to calculate rewards for (default all)")
+	finality := flag.String("finality", "flag", "Handling of non-finalized epochs (can be flag, refuse or wait)")
+	strict := flag.Bool("strict", false, "Fail for epochs computed from execution optimistic data")
+	cacheDir := flag.String("cache", "", "Directory of the cache for finalized beacon and EL responses (default no cache)")
+	storeDir := flag.String("store", "", "Directory of the rewards store, saves the epochs and resumes an interrupted backfill")
+	follow := flag.Bool("follow", false, "Follow the finalized chain starting at -epoch and save the epochs to -store")
+	httpAddr := flag.String("http", "", "Address to serve the rewards of -store on, e.g. :8080")
+	grpcAddr := flag.String("grpc", "", "Address to serve the gRPC rewards service of -store on, e.g. :9090")
+	metricsAddr := flag.String("metrics", "", "Address to export the rewards of -validators as Prometheus metrics on, following the finalized chain from the latest final epoch")
+	reconcile := flag.Bool("reconcile", false, "Compare the computed income of -validators (indices) with their balance changes")
 	flag.Parse()
 
-	client := beacon.NewClient(*clNode, time.Second*30)
+	if *endEpoch < *epoch {
+		*endEpoch = *epoch
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	rewardsApi := int64(0)
-	rewardsBalance := int64(0)
-	for i := *epoch; i < i+225; i++ {
-		rewards, err := ethrewards.GetRewardsForEpoch(i, client, *elNode)
+	elClient, err := elrewards.NewClient(ctx, *elNode, 0)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	defer elClient.Close()
 
+	client := beacon.NewClient(*clNode, time.Second*30)
+	var elSource elrewards.ELRewardSource
+	if *cacheDir != "" {
+		c, err := cache.NewLevelDB(*cacheDir)
 		if err != nil {
 			logrus.Fatal(err)
 		}
+		defer c.Close()
+		client = beacon.NewClientWithCache(*clNode, time.Second*30, c)
+		elSource = elrewards.NewCachedRewardSource(elrewards.NewReceiptRewardSource(elClient, elrewards.DefaultConfig()), c)
+	}
+
+	opts := ethrewards.Options{RejectOptimistic: *strict, ELRewardSource: elSource}
+	switch *finality {
+	case "flag":
+		opts.Finality = ethrewards.FinalityFlag
+	case "refuse":
+		opts.Finality = ethrewards.FinalityRefuse
+	case "wait":
+		opts.Finality = ethrewards.FinalityWait
+	default:
+		logrus.Fatalf("invalid -finality %q", *finality)
+	}
+	if *validators != "" {
+		opts.Validators = strings.Split(*validators, ",")
+	}
+	rewarder := ethrewards.NewRewarder(client, elClient, opts)
+
+	if *reconcile {
+		runReconcile(ctx, rewarder, opts.Validators, *epoch, *endEpoch)
+		return
+	}
+
+	if *metricsAddr != "" {
+		runExporter(ctx, stop, rewarder, client, *metricsAddr, len(opts.Validators))
+		return
+	}
 
-		balance, err := client.Balance((i+1)*32, 195851)
+	if *storeDir != "" {
+		s, err := store.NewLevelDB(*storeDir)
 		if err != nil {
 			logrus.Fatal(err)
 		}
+		defer s.Close()
 
-		balanceNext, err := client.Balance((i+2)*32, 195851)
-		if err != nil {
-			logrus.Fatal(err)
+		if *httpAddr != "" {
+			srv := &http.Server{Addr: *httpAddr, Handler: api.NewServer(s, logrus.StandardLogger())}
+			go func() {
+				err := srv.ListenAndServe()
+				if err != http.ErrServerClosed {
+					logrus.Error(err)
+					stop()
+				}
+			}()
+			defer srv.Shutdown(context.Background())
+		}
+
+		if *grpcAddr != "" {
+			listener, err := net.Listen("tcp", *grpcAddr)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			srv := grpc.NewServer()
+			types.RegisterRewardsServer(srv, grpcapi.NewServer(s, rewarder))
+			go func() {
+				err := srv.Serve(listener)
+				if err != nil {
+					logrus.Error(err)
+					stop()
+				}
+			}()
+			defer srv.GracefulStop()
+		}
+
+		switch {
+		case *follow:
+			err = ethrewards.NewFollower(rewarder, client, s, *epoch).Run(ctx)
+		case *httpAddr != "" || *grpcAddr != "":
+			<-ctx.Done()
+		default:
+			err = rewarder.Backfill(ctx, s, *epoch, *endEpoch)
+		}
+		if err != nil && err != context.Canceled {
+			logrus.Error(err)
 		}
+		return
+	}
+	if *follow || *httpAddr != "" || *grpcAddr != "" {
+		logrus.Fatal("-follow, -http and -grpc require -store")
+	}
 
-		logrus.Infof("epoch %d: %s", i, rewards[uint64(195851)].String())
-		logrus.Infof("epoch %d: %d income", i, rewards[uint64(195851)].TotalClRewards())
-		logrus.Infof("epoch %d: %d balance", i, balance)
-		logrus.Infof("epoch %d: %d balanceNext", i, balanceNext)
-		logrus.Infof("epoch %d: %d delta", i, int64(balanceNext)-int64(balance))
+	for res := range rewarder.GetRewardsForEpochRange(ctx, *epoch, *endEpoch) {
+		if res.Err != nil {
+			logrus.Fatal(res.Err)
+		}
 
-		rewardsApi += rewards[uint64(195851)].TotalClRewards()
-		rewardsBalance += int64(balanceNext) - int64(balance)
+		total := int64(0)
+		for _, income := range res.Rewards {
+			total += income.TotalClRewards()
+		}
+		logrus.Infof("epoch %d: %d validators, %d total income (finalized: %v, optimistic: %v)", res.Epoch, len(res.Rewards), total, res.Finalized, res.Optimistic)
 
-		logrus.Infof("epoch %d: %d api", i, rewardsApi)
-		logrus.Infof("epoch %d: %d balance", i, rewardsBalance)
-		logrus.Info()
+		if len(opts.Validators) > 0 {
+			indices := make([]uint64, 0, len(res.Rewards))
+			for v := range res.Rewards {
+				indices = append(indices, v)
+			}
+			sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+			for _, v := range indices {
+				logrus.Infof("epoch %d: validator %d: %d income, %.2f%% attestation efficiency: %s", res.Epoch, v, res.Rewards[v].TotalClRewards(), res.Rewards[v].AttestationEfficiency(), res.Rewards[v].String())
+			}
+		}
+	}
+}
+
+func runExporter(ctx context.Context, stop context.CancelFunc, rewarder *ethrewards.Rewarder, client *beacon.Client, addr string, validators int) {
+	if validators == 0 {
+		logrus.Fatal("-metrics requires -validators")
+	}
+
+	registry := prometheus.NewRegistry()
+	e, err := exporter.NewExporter(registry)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	srv := &http.Server{Addr: addr, Handler: promhttp.HandlerFor(registry, promhttp.HandlerOpts{})}
+	go func() {
+		err := srv.ListenAndServe()
+		if err != http.ErrServerClosed {
+			logrus.Error(err)
+			stop()
+		}
+	}()
+	defer srv.Shutdown(context.Background())
+
+	// starts at genesis if no epoch is final yet
+	start, _, err := rewarder.LastFinalEpoch(ctx)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	err = ethrewards.NewFollower(rewarder, client, e, start).Run(ctx)
+	if err != nil && err != context.Canceled {
+		logrus.Error(err)
+	}
+}
+
+func runReconcile(ctx context.Context, rewarder *ethrewards.Rewarder, ids []string, startEpoch, endEpoch uint64) {
+	if len(ids) == 0 {
+		logrus.Fatal("-reconcile requires -validators")
+	}
+	validators := make([]uint64, len(ids))
+	for i, id := range ids {
+		v, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			logrus.Fatalf("-reconcile requires validator indices: %v", err)
+		}
+		validators[i] = v
+	}
+
+	reconciliations, err := rewarder.Reconcile(ctx, validators, startEpoch, endEpoch)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	for _, rec := range reconciliations {
+		logrus.Infof("validator %d: balance %d -> %d, income %d, deposits %d, withdrawals %d, discrepancy %d",
+			rec.Validator, rec.StartBalance, rec.EndBalance, rec.Income, rec.Deposits, rec.Withdrawals, rec.Discrepancy)
+		for _, e := range rec.Explanations {
+			logrus.Infof("validator %d: %s", rec.Validator, e)
+		}
 	}
 }
@@ -0,0 +1,45 @@
+package elrewards
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/gobitfly/eth-rewards/cache"
+)
+
+// CachedRewardSource caches the rewards of an ELRewardSource. The reward of a block is immutable once
+// calculated for its hash, so every result is cached.
+type CachedRewardSource struct {
+	source ELRewardSource
+	cache  cache.Cache
+}
+
+func NewCachedRewardSource(source ELRewardSource, c cache.Cache) *CachedRewardSource {
+	return &CachedRewardSource{
+		source: source,
+		cache:  c,
+	}
+}
+
+func (s *CachedRewardSource) ELRewardForBlockHash(ctx context.Context, executionBlockHash string) (*big.Int, error) {
+	key := "el/reward/" + strings.ToLower(executionBlockHash)
+
+	cached, found, err := s.cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return new(big.Int).SetBytes(cached), nil
+	}
+
+	reward, err := s.source.ELRewardForBlockHash(ctx, executionBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	err = s.cache.Put(key, reward.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return reward, nil
+}
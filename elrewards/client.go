@@ -0,0 +1,51 @@
+package elrewards
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a long-lived connection to an EL node. HTTP endpoints share a pool of keep-alive
+// connections. The client is owned by the caller and must be closed when no longer needed.
+type Client struct {
+	rpcClient    *rpc.Client
+	nativeClient *ethclient.Client
+}
+
+// NewClient connects to the EL endpoint. For HTTP endpoints maxConns limits the number of
+// connections kept open to the node, a value <= 0 selects a default of 64.
+func NewClient(ctx context.Context, endpoint string, maxConns int) (*Client, error) {
+	if maxConns <= 0 {
+		maxConns = 64
+	}
+
+	var rpcClient *rpc.Client
+	var err error
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConns = maxConns
+		transport.MaxIdleConnsPerHost = maxConns
+		transport.MaxConnsPerHost = maxConns
+		transport.IdleConnTimeout = time.Minute * 5
+		rpcClient, err = rpc.DialHTTPWithClient(endpoint, &http.Client{Transport: transport})
+	} else {
+		rpcClient, err = rpc.DialContext(ctx, endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		rpcClient:    rpcClient,
+		nativeClient: ethclient.NewClient(rpcClient),
+	}, nil
+}
+
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
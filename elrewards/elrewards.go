@@ -7,30 +7,119 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gobitfly/eth-rewards/types"
 	"github.com/sirupsen/logrus"
 )
 
-func GetELRewardForBlock(executionBlockNumber uint64, endpoint string) (*big.Int, error) {
+// ELRewardSource calculates the execution layer reward the proposer of a block received. Blocks are
+// identified by hash, so the reward does not depend on the EL client's current view of the chain.
+type ELRewardSource interface {
+	ELRewardForBlockHash(ctx context.Context, executionBlockHash string) (*big.Int, error)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-	defer cancel()
+// ReceiptRewardSource is the default ELRewardSource. It sums up the priority fees of all transactions
+// of a block using their receipts.
+type ReceiptRewardSource struct {
+	client *Client
+	config Config
+}
+
+func NewReceiptRewardSource(client *Client, config Config) *ReceiptRewardSource {
+	return &ReceiptRewardSource{
+		client: client,
+		config: config.withDefaults(),
+	}
+}
+
+// RetryPolicy controls how the receipts of a block are re-requested after a failed batch call.
+type RetryPolicy struct {
+	Attempts int           // maximum number of batch calls
+	Timeout  time.Duration // timeout of a single batch call
+	Backoff  time.Duration // delay before the next attempt, multiplied by the attempt number
+}
+
+type Config struct {
+	Timeout time.Duration // overall timeout for calculating the reward of one block
+	Retry   RetryPolicy
+	Logger  logrus.FieldLogger
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout: time.Second * 30,
+		Retry: RetryPolicy{
+			Attempts: 16,
+			Timeout:  time.Second * 16,
+			Backoff:  time.Second,
+		},
+		Logger: logrus.StandardLogger(),
+	}
+}
+
+// withDefaults replaces unset fields with the values of DefaultConfig.
+func (c Config) withDefaults() Config {
+	d := DefaultConfig()
+	if c.Timeout <= 0 {
+		c.Timeout = d.Timeout
+	}
+	if c.Retry.Attempts <= 0 {
+		c.Retry.Attempts = d.Retry.Attempts
+	}
+	if c.Retry.Timeout <= 0 {
+		c.Retry.Timeout = d.Retry.Timeout
+	}
+	if c.Retry.Backoff <= 0 {
+		c.Retry.Backoff = d.Retry.Backoff
+	}
+	if c.Logger == nil {
+		c.Logger = d.Logger
+	}
+	return c
+}
 
-	rpcClient, err := rpc.Dial(endpoint)
+func GetELRewardForBlock(ctx context.Context, executionBlockNumber uint64, endpoint string) (*big.Int, error) {
+	return GetELRewardForBlockWithConfig(ctx, executionBlockNumber, endpoint, DefaultConfig())
+}
+
+// GetELRewardForBlockWithConfig connects to the endpoint for a single block. Use a ReceiptRewardSource
+// with a long-lived Client to calculate the rewards of many blocks.
+func GetELRewardForBlockWithConfig(ctx context.Context, executionBlockNumber uint64, endpoint string, config Config) (*big.Int, error) {
+	client, err := NewClient(ctx, endpoint, 1)
 	if err != nil {
 		return nil, err
 	}
-	nativeClient, err := ethclient.Dial(endpoint)
+	defer client.Close()
+
+	return NewReceiptRewardSource(client, config).ELRewardForBlock(ctx, executionBlockNumber)
+}
+
+func (s *ReceiptRewardSource) ELRewardForBlock(ctx context.Context, executionBlockNumber uint64) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	block, err := s.client.nativeClient.BlockByNumber(ctx, big.NewInt(int64(executionBlockNumber)))
 	if err != nil {
 		return nil, err
 	}
+	return s.rewardForBlock(ctx, block)
+}
+
+func (s *ReceiptRewardSource) ELRewardForBlockHash(ctx context.Context, executionBlockHash string) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
 
-	block, err := nativeClient.BlockByNumber(ctx, big.NewInt(int64(executionBlockNumber)))
+	block, err := s.client.nativeClient.BlockByHash(ctx, common.HexToHash(executionBlockHash))
 	if err != nil {
 		return nil, err
 	}
+	return s.rewardForBlock(ctx, block)
+}
+
+func (s *ReceiptRewardSource) rewardForBlock(ctx context.Context, block *gethtypes.Block) (*big.Int, error) {
+	config := s.config
+	executionBlockNumber := block.NumberU64()
 
 	if len(block.Transactions()) == 0 {
 		return big.NewInt(0), nil
@@ -42,17 +131,26 @@ func GetELRewardForBlock(executionBlockNumber uint64, endpoint string) (*big.Int
 	}
 
 	var txReceipts []*types.TxReceipt
-	for j := 1; j <= 16; j++ { // retry up to 16 times
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*16)
-		txReceipts, err = batchRequestReceipts(ctx, rpcClient, txHashes)
+	var err error
+	for j := 1; j <= config.Retry.Attempts; j++ {
+		reqCtx, reqCancel := context.WithTimeout(ctx, config.Retry.Timeout)
+		txReceipts, err = batchRequestReceipts(reqCtx, s.client.rpcClient, txHashes)
+		reqCancel()
 		if err == nil {
-			cancel()
 			break
-		} else {
-			logrus.Infof("error (%d) doing batchRequestReceipts for execution block %v: %v", j, executionBlockNumber, err)
-			time.Sleep(time.Duration(j) * time.Second)
 		}
-		cancel()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if j == config.Retry.Attempts {
+			break
+		}
+		config.Logger.Infof("error (%d) doing batchRequestReceipts for execution block %v: %v", j, executionBlockNumber, err)
+		select {
+		case <-time.After(time.Duration(j) * config.Retry.Backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error doing batchRequestReceipts for execution block %v: %w", executionBlockNumber, err)
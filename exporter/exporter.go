@@ -0,0 +1,153 @@
+package exporter
+
+import (
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/gobitfly/eth-rewards/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "eth_rewards"
+
+// components are the income components exported per validator, penalties end in _penalty.
+var components = []struct {
+	name  string
+	value func(*types.ValidatorEpochIncome) uint64
+}{
+	{"attestation_source_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.AttestationSourceReward }},
+	{"attestation_source_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.AttestationSourcePenalty }},
+	{"attestation_target_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.AttestationTargetReward }},
+	{"attestation_target_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.AttestationTargetPenalty }},
+	{"attestation_head_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.AttestationHeadReward }},
+	{"finality_delay_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.FinalityDelayPenalty }},
+	{"inactivity_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.InactivityPenalty }},
+	{"sync_committee_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.SyncCommitteeReward }},
+	{"sync_committee_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.SyncCommitteePenalty }},
+	{"proposer_attestation_inclusion_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.ProposerAttestationInclusionReward }},
+	{"proposer_sync_inclusion_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.ProposerSyncInclusionReward }},
+	{"proposer_slashing_inclusion_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.ProposerSlashingInclusionReward }},
+	{"slashing_reward", func(i *types.ValidatorEpochIncome) uint64 { return i.SlashingReward }},
+	{"slashing_penalty", func(i *types.ValidatorEpochIncome) uint64 { return i.SlashingPenalty }},
+}
+
+// Exporter publishes the income of the validators as Prometheus metrics. It is an ethrewards.Sink, so it
+// can be fed by a Follower.
+type Exporter struct {
+	mux       sync.Mutex
+	lastEpoch uint64
+	exported  bool
+
+	epochGwei        *prometheus.GaugeVec
+	gweiTotal        *prometheus.CounterVec
+	epochTotalCl     *prometheus.GaugeVec
+	elFeesWei        *prometheus.GaugeVec
+	elFeesWeiTotal   *prometheus.CounterVec
+	proposalsMissed  *prometheus.CounterVec
+	missedRewardGwei *prometheus.CounterVec
+	lastEpochGauge   prometheus.Gauge
+}
+
+// NewExporter creates an Exporter and registers its metrics with the registerer.
+func NewExporter(registerer prometheus.Registerer) (*Exporter, error) {
+	e := &Exporter{
+		epochGwei: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validator_epoch_gwei",
+			Help:      "Income component of the validator in the last exported epoch in gwei.",
+		}, []string{"validator", "component"}),
+		gweiTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_gwei_total",
+			Help:      "Income component of the validator summed up over the exported epochs in gwei.",
+		}, []string{"validator", "component"}),
+		epochTotalCl: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validator_epoch_cl_income_gwei",
+			Help:      "Total CL income of the validator in the last exported epoch in gwei, negative if penalized.",
+		}, []string{"validator"}),
+		elFeesWei: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validator_epoch_el_fees_wei",
+			Help:      "Tx fee rewards of the validator in the last exported epoch in wei.",
+		}, []string{"validator"}),
+		elFeesWeiTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_el_fees_wei_total",
+			Help:      "Tx fee rewards of the validator summed up over the exported epochs in wei.",
+		}, []string{"validator"}),
+		proposalsMissed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_proposals_missed_total",
+			Help:      "Missed block proposals of the validator.",
+		}, []string{"validator"}),
+		missedRewardGwei: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_missed_rewards_gwei_total",
+			Help:      "CL rewards the validator forwent by missed duties in gwei.",
+		}, []string{"validator", "duty"}),
+		lastEpochGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_epoch",
+			Help:      "Last exported epoch.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{e.epochGwei, e.gweiTotal, e.epochTotalCl, e.elFeesWei, e.elFeesWeiTotal,
+		e.proposalsMissed, e.missedRewardGwei, e.lastEpochGauge} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// SaveEpoch exports the income of the epoch.
+func (e *Exporter) SaveEpoch(epoch uint64, rewards map[uint64]*types.ValidatorEpochIncome) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	for v, income := range rewards {
+		validator := strconv.FormatUint(v, 10)
+		for _, c := range components {
+			value := float64(c.value(income))
+			e.epochGwei.WithLabelValues(validator, c.name).Set(value)
+			e.gweiTotal.WithLabelValues(validator, c.name).Add(value)
+		}
+		e.epochTotalCl.WithLabelValues(validator).Set(float64(income.TotalClRewards()))
+
+		fees, _ := new(big.Float).SetInt(new(big.Int).SetBytes(income.TxFeeRewardWei)).Float64()
+		e.elFeesWei.WithLabelValues(validator).Set(fees)
+		e.elFeesWeiTotal.WithLabelValues(validator).Add(fees)
+
+		e.proposalsMissed.WithLabelValues(validator).Add(float64(income.ProposalsMissed))
+		e.missedRewardGwei.WithLabelValues(validator, "attestation").Add(float64(income.MissedAttestationRewards()))
+		e.missedRewardGwei.WithLabelValues(validator, "proposal").Add(float64(income.ProposalsMissedClReward))
+		e.missedRewardGwei.WithLabelValues(validator, "sync_committee").Add(float64(income.SyncCommitteeMissedReward))
+	}
+
+	e.lastEpoch = epoch
+	e.exported = true
+	e.lastEpochGauge.Set(float64(epoch))
+	return nil
+}
+
+// LastCompleted returns the last exported epoch. The metrics are not persisted, after a restart the
+// export starts again at the follower's start epoch.
+func (e *Exporter) LastCompleted() (uint64, bool, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.lastEpoch, e.exported, nil
+}
+
+// FirstIncomplete returns the epoch after the last exported epoch, or start if it is later. Epochs before
+// the last exported epoch are never exported again.
+func (e *Exporter) FirstIncomplete(start uint64) (uint64, error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.exported && e.lastEpoch+1 > start {
+		return e.lastEpoch + 1, nil
+	}
+	return start, nil
+}
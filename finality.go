@@ -0,0 +1,70 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+type finalityStatus struct {
+	finalized  bool
+	optimistic bool
+}
+
+// finality checks whether the epoch is finalized and handles non-finalized epochs according to
+// Options.Finality.
+//
+// The attestation rewards of an epoch depend on the blocks of the following epoch, so if they are computed
+// the following epoch has to be finalized as well.
+func (r *Rewarder) finality(ctx context.Context, epoch uint64) (*finalityStatus, error) {
+	required := epoch + r.finalityDelay()
+
+	for {
+		checkpoints, err := r.client.FinalityCheckpoints(ctx, "head")
+		if err != nil {
+			return nil, err
+		}
+		status := &finalityStatus{
+			finalized:  checkpoints.Data.Finalized.Epoch >= required,
+			optimistic: checkpoints.ExecutionOptimistic,
+		}
+		if status.finalized || r.opts.Finality == FinalityFlag {
+			return status, nil
+		}
+		if r.opts.Finality == FinalityRefuse {
+			return nil, fmt.Errorf("%w: epoch %v, finalized checkpoint is at epoch %v", types.ErrEpochNotFinalized, epoch, checkpoints.Data.Finalized.Epoch)
+		}
+
+		r.log.Infof("waiting for epoch %v to be finalized, finalized checkpoint is at epoch %v", epoch, checkpoints.Data.Finalized.Epoch)
+		select {
+		case <-time.After(r.opts.FinalityPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// finalityDelay is the number of epochs the finalized checkpoint has to be ahead of an epoch for its income
+// to be final.
+func (r *Rewarder) finalityDelay() uint64 {
+	if r.computes(ComponentAttestations) {
+		return 2
+	}
+	return 1
+}
+
+// LastFinalEpoch returns the last epoch whose income is final at the beacon node's current finalized
+// checkpoint, false if there is none.
+func (r *Rewarder) LastFinalEpoch(ctx context.Context) (uint64, bool, error) {
+	checkpoints, err := r.client.FinalityCheckpoints(ctx, "head")
+	if err != nil {
+		return 0, false, err
+	}
+	finalizedEpoch := checkpoints.Data.Finalized.Epoch
+	if finalizedEpoch < r.finalityDelay() {
+		return 0, false, nil
+	}
+	return finalizedEpoch - r.finalityDelay(), true, nil
+}
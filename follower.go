@@ -0,0 +1,157 @@
+package ethrewards
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// followerRetryInterval is the delay before a failed catch up is retried or the event stream is
+// resubscribed.
+const followerRetryInterval = time.Second * 12
+
+// EventSource is the beacon node's event stream. It is implemented by *beacon.Client.
+type EventSource interface {
+	Events(ctx context.Context, topics []string, handler func(*types.Event) error) error
+}
+
+// Sink receives the income of the finalized epochs computed by a Follower. It is implemented by
+// store.Store.
+type Sink interface {
+	SaveEpoch(epoch uint64, rewards map[uint64]*types.ValidatorEpochIncome) error
+	// FirstIncomplete returns the first epoch at or after start that was not saved yet.
+	FirstIncomplete(start uint64) (uint64, error)
+}
+
+// Follower follows the finalized chain and hands the income of every newly finalized epoch to a sink.
+type Follower struct {
+	rewarder   *Rewarder
+	events     EventSource
+	sink       Sink
+	startEpoch uint64
+}
+
+// NewFollower creates a Follower. It continues at the first epoch from startEpoch on that is missing in
+// the sink.
+func NewFollower(rewarder *Rewarder, events EventSource, sink Sink, startEpoch uint64) *Follower {
+	return &Follower{
+		rewarder:   rewarder,
+		events:     events,
+		sink:       sink,
+		startEpoch: startEpoch,
+	}
+}
+
+// Run follows the chain until ctx is cancelled. It catches up on all finalized epochs missing in the sink
+// when started, after every finalized checkpoint and after the event stream was resubscribed.
+func (f *Follower) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	trigger := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+	notify()
+	go f.subscribe(ctx, notify)
+
+	for {
+		select {
+		case <-trigger:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		err := f.catchUp(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			f.rewarder.log.Errorf("error catching up with the finalized chain: %v", err)
+			time.AfterFunc(followerRetryInterval, notify)
+		}
+	}
+}
+
+// subscribe keeps the subscription to the event stream alive and triggers a catch up for every finalized
+// checkpoint and every resubscription.
+func (f *Follower) subscribe(ctx context.Context, notify func()) {
+	topics := []string{"head", "finalized_checkpoint", "chain_reorg"}
+	log := f.rewarder.log
+	for {
+		err := f.events.Events(ctx, topics, func(event *types.Event) error {
+			switch event.Topic {
+			case "head":
+				var head types.HeadEvent
+				if err := json.Unmarshal(event.Data, &head); err != nil {
+					return err
+				}
+				log.Debugf("new head %v at slot %v", head.Block, head.Slot)
+			case "finalized_checkpoint":
+				var checkpoint types.FinalizedCheckpointEvent
+				if err := json.Unmarshal(event.Data, &checkpoint); err != nil {
+					return err
+				}
+				log.Infof("epoch %v finalized", checkpoint.Epoch)
+				notify()
+			case "chain_reorg":
+				var reorg types.ChainReorgEvent
+				if err := json.Unmarshal(event.Data, &reorg); err != nil {
+					return err
+				}
+				log.Warnf("chain reorg of depth %v at slot %v, new head %v", reorg.Depth, reorg.Slot, reorg.NewHeadBlock)
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("error subscribing to beacon node events: %v", err)
+
+		select {
+		case <-time.After(followerRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+		// events may have been missed while the subscription was down
+		notify()
+	}
+}
+
+// catchUp computes and saves all epochs from the last epoch of the sink to the last epoch whose income
+// is final.
+func (f *Follower) catchUp(ctx context.Context) error {
+	// epochs after a gap are computed again and overwritten
+	next, err := f.sink.FirstIncomplete(f.startEpoch)
+	if err != nil {
+		return err
+	}
+
+	end, found, err := f.rewarder.LastFinalEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	if !found || end < next {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for res := range f.rewarder.GetRewardsForEpochRange(ctx, next, end) {
+		if res.Err != nil {
+			return res.Err
+		}
+		err := f.sink.SaveEpoch(res.Epoch, res.Rewards)
+		if err != nil {
+			return err
+		}
+		f.rewarder.log.Infof("saved epoch %v: %v validators", res.Epoch, len(res.Rewards))
+	}
+	return ctx.Err()
+}
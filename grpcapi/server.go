@@ -0,0 +1,245 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	ethrewards "github.com/gobitfly/eth-rewards"
+	"github.com/gobitfly/eth-rewards/store"
+	"github.com/gobitfly/eth-rewards/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamBatchEpochs is the number of epochs StreamValidatorRewards reads from the store at once.
+const streamBatchEpochs = 225
+
+// Server implements the Rewards gRPC service on top of a store. Register it with
+// types.RegisterRewardsServer.
+type Server struct {
+	types.UnimplementedRewardsServer
+
+	store    store.Store
+	rewarder *ethrewards.Rewarder
+}
+
+// NewServer creates a Server. If rewarder is not nil, GetEpochRewards computes epochs that are not
+// complete in the store if they are finalized and not optimistic.
+func NewServer(s store.Store, rewarder *ethrewards.Rewarder) *Server {
+	return &Server{
+		store:    s,
+		rewarder: rewarder,
+	}
+}
+
+func (s *Server) GetEpochRewards(ctx context.Context, req *types.GetEpochRewardsRequest) (*types.GetEpochRewardsResponse, error) {
+	// the income of all validators of an epoch exceeds the default message size limit
+	if len(req.Validators) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "validators are required, use StreamValidatorRewards for all validators")
+	}
+
+	rewards, err := s.store.Epoch(req.Epoch)
+	if errors.Is(err, store.ErrEpochNotFound) && s.rewarder != nil {
+		rewards, err = s.computeEpoch(ctx, req.Epoch)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	filtered := make(map[uint64]*types.ValidatorEpochIncome, len(req.Validators))
+	efficiency := make(map[uint64]float64, len(req.Validators))
+	for _, v := range req.Validators {
+		if income := rewards[v]; income != nil {
+			filtered[v] = income
+			efficiency[v] = income.AttestationEfficiency()
+		}
+	}
+
+	return &types.GetEpochRewardsResponse{
+		Epoch:                 req.Epoch,
+		Rewards:               filtered,
+		AttestationEfficiency: efficiency,
+	}, nil
+}
+
+// computeEpoch computes an epoch missing in the store. Only the final income is served, like the income
+// in the store.
+func (s *Server) computeEpoch(ctx context.Context, epoch uint64) (map[uint64]*types.ValidatorEpochIncome, error) {
+	res, err := s.rewarder.GetEpochRewards(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Finalized {
+		return nil, fmt.Errorf("%w: epoch %v", types.ErrEpochNotFinalized, epoch)
+	}
+	if res.Optimistic {
+		return nil, fmt.Errorf("%w: epoch %v", types.ErrExecutionOptimistic, epoch)
+	}
+	return res.Rewards, nil
+}
+
+func (s *Server) StreamValidatorRewards(req *types.StreamValidatorRewardsRequest, stream types.Rewards_StreamValidatorRewardsServer) error {
+	from, to, err := s.epochRange(req.FromEpoch, req.ToEpoch)
+	if err != nil {
+		return err
+	}
+
+	validators := make([]uint64, 0, len(req.Validators))
+	seen := make(map[uint64]bool, len(req.Validators))
+	for _, v := range req.Validators {
+		if !seen[v] {
+			seen[v] = true
+			validators = append(validators, v)
+		}
+	}
+	sort.Slice(validators, func(i, j int) bool { return validators[i] < validators[j] })
+
+	for batchStart := from; batchStart <= to; batchStart += streamBatchEpochs {
+		if err := stream.Context().Err(); err != nil {
+			return statusError(err)
+		}
+
+		batchEnd := to
+		if to-batchStart >= streamBatchEpochs {
+			batchEnd = batchStart + streamBatchEpochs - 1
+		}
+
+		var epochs map[uint64]map[uint64]*types.ValidatorEpochIncome
+		if len(validators) > 0 {
+			epochs, err = s.validatorEpochs(validators, batchStart, batchEnd)
+		} else {
+			epochs, err = s.epochs(batchStart, batchEnd)
+		}
+		if err != nil {
+			return statusError(err)
+		}
+
+		for e := batchStart; e <= batchEnd; e++ {
+			rewards := epochs[e]
+			indices := make([]uint64, 0, len(rewards))
+			for v := range rewards {
+				indices = append(indices, v)
+			}
+			sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+			for _, v := range indices {
+				err := stream.Send(&types.ValidatorRewards{
+					Epoch:                 e,
+					Validator:             v,
+					Income:                rewards[v],
+					AttestationEfficiency: rewards[v].AttestationEfficiency(),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if e == ^uint64(0) {
+				break
+			}
+		}
+
+		if batchEnd == to {
+			break
+		}
+	}
+	return nil
+}
+
+// validatorEpochs returns the income of the validators in the complete epochs from start to end (inclusive)
+// by epoch and validator.
+func (s *Server) validatorEpochs(validators []uint64, start, end uint64) (map[uint64]map[uint64]*types.ValidatorEpochIncome, error) {
+	epochs := make(map[uint64]map[uint64]*types.ValidatorEpochIncome)
+	for _, v := range validators {
+		rewards, err := s.store.ValidatorEpochs(v, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for e, income := range rewards {
+			if epochs[e] == nil {
+				epochs[e] = make(map[uint64]*types.ValidatorEpochIncome)
+			}
+			epochs[e][v] = income
+		}
+	}
+	return epochs, nil
+}
+
+// epochs returns the income of all validators in the complete epochs from start to end (inclusive) by epoch.
+func (s *Server) epochs(start, end uint64) (map[uint64]map[uint64]*types.ValidatorEpochIncome, error) {
+	epochs := make(map[uint64]map[uint64]*types.ValidatorEpochIncome)
+	for e := start; e <= end; e++ {
+		rewards, err := s.store.Epoch(e)
+		if errors.Is(err, store.ErrEpochNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		epochs[e] = rewards
+		if e == ^uint64(0) {
+			break
+		}
+	}
+	return epochs, nil
+}
+
+func (s *Server) GetAggregate(ctx context.Context, req *types.GetAggregateRequest) (*types.GetAggregateResponse, error) {
+	from, to, err := s.epochRange(req.FromEpoch, req.ToEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate, err := store.Aggregated(s.store, from, to, req.Validators)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &types.GetAggregateResponse{
+		FromEpoch:             from,
+		ToEpoch:               to,
+		Epochs:                uint64(aggregate.Epochs),
+		Validators:            uint64(aggregate.Validators),
+		Income:                aggregate.Income,
+		AttestationEfficiency: aggregate.Income.AttestationEfficiency(),
+	}, nil
+}
+
+// epochRange validates the requested epoch range. An unset to epoch selects the last complete epoch.
+func (s *Server) epochRange(from uint64, to *uint64) (uint64, uint64, error) {
+	var end uint64
+	if to != nil {
+		end = *to
+	} else {
+		last, found, err := s.store.LastCompleted()
+		if err != nil {
+			return 0, 0, statusError(err)
+		}
+		if !found {
+			return 0, 0, statusError(store.ErrEpochNotFound)
+		}
+		end = last
+	}
+	if end < from {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid epoch range %v - %v", from, end)
+	}
+	return from, end, nil
+}
+
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrEpochNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, store.ErrRangeTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrEpochNotFinalized), errors.Is(err, types.ErrExecutionOptimistic):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,98 @@
+package ethrewards
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// maxIdealValidatorIDs is the maximum number of validators whose effective balance is requested by index,
+// above all validators of the state are requested.
+const maxIdealValidatorIDs = 1024
+
+// idealAttestationRewards matches each validator of the attestation rewards with the ideal rewards for its
+// effective balance.
+//
+// A positive source, target or head reward equals the ideal reward of the validator's effective balance, so
+// the effective balance is derived from it. Only for validators without any positive reward the effective
+// balance is requested from the beacon node, using the state at the end of the epoch the rewards are
+// processed in. If the computation is not restricted to a validator set or many validators are unresolved,
+// all validators of the state are requested in a single request.
+func (r *Rewarder) idealAttestationRewards(ctx context.Context, epoch, slotsPerEpoch uint64, ar *types.AttestationRewardsApiResponse) (map[uint64]*types.IdealAttestationRewardContainer, error) {
+	byEffectiveBalance := make(map[int64]*types.IdealAttestationRewardContainer)
+	bySource := make(map[int64]*types.IdealAttestationRewardContainer)
+	byTarget := make(map[int64]*types.IdealAttestationRewardContainer)
+	byHead := make(map[int64]*types.IdealAttestationRewardContainer)
+	for _, ideal := range ar.Data.IdealRewards {
+		byEffectiveBalance[ideal.EffectiveBalance] = ideal
+		if ideal.Source > 0 {
+			bySource[ideal.Source] = ideal
+		}
+		if ideal.Target > 0 {
+			byTarget[ideal.Target] = ideal
+		}
+		if ideal.Head > 0 {
+			byHead[ideal.Head] = ideal
+		}
+	}
+
+	ideals := make(map[uint64]*types.IdealAttestationRewardContainer, len(ar.Data.TotalRewards))
+	var unresolved []uint64
+	for _, total := range ar.Data.TotalRewards {
+		var ideal *types.IdealAttestationRewardContainer
+		switch {
+		case total.Source > 0:
+			ideal = bySource[total.Source]
+		case total.Target > 0:
+			ideal = byTarget[total.Target]
+		case total.Head > 0:
+			ideal = byHead[total.Head]
+		}
+		if ideal != nil {
+			ideals[total.ValidatorIndex] = ideal
+		} else {
+			unresolved = append(unresolved, total.ValidatorIndex)
+		}
+	}
+
+	if len(unresolved) == 0 {
+		return ideals, nil
+	}
+
+	stateID := strconv.FormatUint((epoch+2)*slotsPerEpoch-1, 10)
+	var ids []string
+	if len(r.opts.Validators) > 0 && len(unresolved) <= maxIdealValidatorIDs {
+		ids = make([]string, len(unresolved))
+		for i, v := range unresolved {
+			ids[i] = strconv.FormatUint(v, 10)
+		}
+	}
+	validators, err := r.client.Validators(ctx, stateID, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveBalances := make(map[uint64]uint64, len(validators.Data))
+	for _, v := range validators.Data {
+		effectiveBalances[v.Index] = v.EffectiveBalance
+	}
+	for _, v := range unresolved {
+		effectiveBalance, found := effectiveBalances[v]
+		if !found {
+			continue
+		}
+		if ideal := byEffectiveBalance[int64(effectiveBalance)]; ideal != nil {
+			ideals[v] = ideal
+		}
+	}
+	return ideals, nil
+}
+
+// missedReward is the difference between the ideal and the actual reward, including penalties.
+func missedReward(ideal, actual int64) uint64 {
+	if actual >= ideal {
+		return 0
+	}
+	return uint64(ideal - actual)
+}
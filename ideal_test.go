@@ -0,0 +1,89 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// validatorsBeacon serves validators with an effective balance of 31 ETH at odd and 32 ETH at even indices
+// and records the requested ids.
+type validatorsBeacon struct {
+	*mockBeacon
+	requests [][]string
+}
+
+func (m *validatorsBeacon) Validators(ctx context.Context, stateID string, ids []string, statuses []string) (*types.ValidatorsApiResponse, error) {
+	if stateID != strconv.FormatUint(4*32-1, 10) {
+		return nil, fmt.Errorf("unexpected state %v", stateID)
+	}
+	m.requests = append(m.requests, ids)
+
+	r := &types.ValidatorsApiResponse{}
+	validator := func(index uint64) *types.ValidatorContainer {
+		return &types.ValidatorContainer{Index: index, EffectiveBalance: 32000000000 - index%2*1000000000}
+	}
+	if len(ids) == 0 {
+		for i := uint64(0); i < 5000; i++ {
+			r.Data = append(r.Data, validator(i))
+		}
+	}
+	for _, id := range ids {
+		index, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		r.Data = append(r.Data, validator(index))
+	}
+	return r, nil
+}
+
+func TestIdealAttestationRewards(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators []string
+		unresolved uint64
+		requested  int // ids of the validators request, 0 for all validators
+	}{
+		{"all validators", nil, 3000, 0},
+		{"validator set", []string{"1", "2"}, 2, 2},
+		{"large validator set", []string{"1", "2"}, maxIdealValidatorIDs + 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// during an inactivity leak all ideal rewards are 0, the validators are only resolved by their
+			// effective balance
+			ar := &types.AttestationRewardsApiResponse{}
+			ar.Data.IdealRewards = []*types.IdealAttestationRewardContainer{
+				{EffectiveBalance: 31000000000},
+				{EffectiveBalance: 32000000000},
+			}
+			for i := uint64(0); i < tt.unresolved; i++ {
+				ar.Data.TotalRewards = append(ar.Data.TotalRewards, &types.TotalAttestationRewardsContainer{ValidatorIndex: i + 1, Source: -10})
+			}
+
+			client := &validatorsBeacon{mockBeacon: &mockBeacon{slotsPerEpoch: 32}}
+			r := NewRewarder(client, nil, Options{Validators: tt.validators})
+
+			ideals, err := r.idealAttestationRewards(context.Background(), 2, 32, ar)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(client.requests) != 1 || len(client.requests[0]) != tt.requested {
+				t.Fatalf("expected a single request for %v ids, got %v requests", tt.requested, len(client.requests))
+			}
+			if uint64(len(ideals)) != tt.unresolved {
+				t.Fatalf("resolved %v validators", len(ideals))
+			}
+			for v, ideal := range ideals {
+				if ideal.EffectiveBalance != int64(32000000000-v%2*1000000000) {
+					t.Errorf("validator %v matched the ideal rewards of %v", v, ideal.EffectiveBalance)
+				}
+			}
+		})
+	}
+}
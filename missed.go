@@ -0,0 +1,142 @@
+package ethrewards
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// missedProposalNeighbors is the number of proposed blocks around a missed slot the forgone rewards are
+// estimated from.
+const missedProposalNeighbors = 4
+
+// missedProposalRewards estimates the rewards forgone by the proposers of missed slots. The CL reward is the
+// median of the block rewards and the EL reward the median of the tx fee rewards of the closest proposed
+// blocks of the epoch.
+func (r *Rewarder) missedProposalRewards(ctx context.Context, slots []*SlotReward, proposers map[uint64]*types.EpochProposerAssignmentsContainer, rewards map[uint64]*types.ValidatorEpochIncome) error {
+	blockRewards := make(map[uint64]uint64)
+	elFees := make(map[uint64]*big.Int)
+	missed := make(map[uint64]bool)
+
+	// neighbor fetches the rewards of a proposed slot and reports whether it was missed
+	neighbor := func(slot *SlotReward) (bool, error) {
+		if slot.Missed || missed[slot.Slot] {
+			return false, nil
+		}
+		if _, found := blockRewards[slot.Slot]; found {
+			return true, nil
+		}
+
+		if slot.BlockReward != nil {
+			blockRewards[slot.Slot] = slot.BlockReward.Total
+		} else {
+			br, err := r.client.BlockRewards(ctx, blockID(slot))
+			if err != nil {
+				if err == types.ErrBlockNotFound {
+					missed[slot.Slot] = true
+					return false, nil
+				}
+				return false, err
+			}
+			blockRewards[slot.Slot] = br.Data.Total
+		}
+
+		if !r.computes(ComponentExecutionFees) {
+			return true, nil
+		}
+		if slot.ELFeeWei != nil {
+			elFees[slot.Slot] = slot.ELFeeWei
+			return true, nil
+		}
+		block, err := r.client.Block(ctx, blockID(slot))
+		if err != nil {
+			return false, err
+		}
+		if block.Data.ExecutionPayload == nil {
+			return true, nil
+		}
+		fee, err := r.el.ELRewardForBlockHash(ctx, block.Data.ExecutionPayload.BlockHash)
+		if err != nil {
+			return false, err
+		}
+		elFees[slot.Slot] = fee
+		return true, nil
+	}
+
+	for i, slot := range slots {
+		if !slot.Missed || !r.includesProposer(proposers[slot.Slot]) {
+			continue
+		}
+
+		var clRewards []uint64
+		var elRewards []*big.Int
+		for d := 1; d < len(slots) && len(clRewards) < missedProposalNeighbors; d++ {
+			for _, j := range []int{i - d, i + d} {
+				if j < 0 || j >= len(slots) || len(clRewards) >= missedProposalNeighbors {
+					continue
+				}
+				proposed, err := neighbor(slots[j])
+				if err != nil {
+					return err
+				}
+				if !proposed {
+					continue
+				}
+				clRewards = append(clRewards, blockRewards[slots[j].Slot])
+				if fee := elFees[slots[j].Slot]; fee != nil {
+					elRewards = append(elRewards, fee)
+				}
+			}
+		}
+
+		slot.EstimatedClReward = medianUint64(clRewards)
+		income := rewards[slot.Proposer]
+		if income == nil {
+			income = &types.ValidatorEpochIncome{}
+			rewards[slot.Proposer] = income
+		}
+		income.ProposalsMissedClReward += slot.EstimatedClReward
+		if r.computes(ComponentExecutionFees) {
+			slot.EstimatedELFeeWei = medianBig(elRewards)
+			total := new(big.Int).SetBytes(income.ProposalsMissedElRewardWei)
+			income.ProposalsMissedElRewardWei = total.Add(total, slot.EstimatedELFeeWei).Bytes()
+		}
+	}
+	return nil
+}
+
+// blockID identifies the slot's block by root if it was fetched and by slot otherwise.
+func blockID(slot *SlotReward) string {
+	if slot.BlockRoot != "" {
+		return slot.BlockRoot
+	}
+	return strconv.FormatUint(slot.Slot, 10)
+}
+
+func medianUint64(values []uint64) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	m := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[m]
+	}
+	return values[m-1]/2 + values[m]/2 + (values[m-1]%2+values[m]%2)/2
+}
+
+func medianBig(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+	m := len(values) / 2
+	if len(values)%2 == 1 {
+		return new(big.Int).Set(values[m])
+	}
+	median := new(big.Int).Add(values[m-1], values[m])
+	return median.Div(median, big.NewInt(2))
+}
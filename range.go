@@ -0,0 +1,94 @@
+package ethrewards
+
+import (
+	"context"
+	"math"
+
+	"github.com/gobitfly/eth-rewards/elrewards"
+)
+
+// EpochResult is the outcome of computing a single epoch of a range. EpochRewards is nil if Err is set.
+type EpochResult struct {
+	Epoch uint64
+	*EpochRewards
+	Err error
+}
+
+// GetRewardsForEpochRange computes the epochs from start to end (inclusive) and delivers the results
+// in order on the returned channel. Up to Options.Pipeline epochs are computed concurrently, a failed
+// epoch is reported in its result and does not stop the range. The channel is closed after the last
+// epoch or once ctx is cancelled and the epochs already started have returned. A caller that stops reading
+// before the channel is closed must cancel ctx, e.g. by deriving it with context.WithCancel and deferring
+// the cancel, otherwise the pending computations are never stopped.
+func (r *Rewarder) GetRewardsForEpochRange(ctx context.Context, start, end uint64) <-chan *EpochResult {
+	out := make(chan *EpochResult)
+	pending := make(chan chan *EpochResult, r.opts.Pipeline-1)
+
+	go func() {
+		defer close(pending)
+		for epoch := start; epoch <= end; epoch++ {
+			res := make(chan *EpochResult, 1)
+			select {
+			case pending <- res:
+			case <-ctx.Done():
+				return
+			}
+
+			epoch := epoch
+			go func() {
+				rewards, err := r.GetEpochRewards(ctx, epoch)
+				res <- &EpochResult{Epoch: epoch, EpochRewards: rewards, Err: err}
+			}()
+
+			if epoch == math.MaxUint64 {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for res := range pending {
+			// waits for every started computation, so none is running once the channel is closed
+			result := <-res
+			if ctx.Err() != nil {
+				continue
+			}
+			select {
+			case out <- result:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return out
+}
+
+// GetRewardsForEpochRange computes the epochs from start to end (inclusive) using the default options,
+// see Rewarder.GetRewardsForEpochRange. The EL connection is closed once the returned channel is closed.
+func GetRewardsForEpochRange(ctx context.Context, start, end uint64, client BeaconAPI, elEndpoint string) (<-chan *EpochResult, error) {
+	opts := DefaultOptions()
+	elClient, err := elrewards.NewClient(ctx, elEndpoint, opts.Concurrency*opts.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	results := NewRewarder(client, elClient, opts).GetRewardsForEpochRange(ctx, start, end)
+
+	out := make(chan *EpochResult)
+	go func() {
+		defer close(out)
+		defer elClient.Close()
+		// results is drained after ctx is cancelled, it is closed once no epoch uses the EL client anymore
+		for res := range results {
+			if ctx.Err() != nil {
+				continue
+			}
+			select {
+			case out <- res:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package ethrewards
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// rangeBeacon fails the computation of the epoch fail and blocks the computations of the epochs from block
+// on until they are cancelled.
+type rangeBeacon struct {
+	*mockBeacon
+	fail    uint64
+	block   uint64
+	running int32
+}
+
+var errEpochFailed = errors.New("epoch failed")
+
+func (m *rangeBeacon) ProposerAssignments(ctx context.Context, epoch uint64) (*types.EpochProposerAssignmentsApiResponse, error) {
+	atomic.AddInt32(&m.running, 1)
+	defer atomic.AddInt32(&m.running, -1)
+
+	if epoch == m.fail {
+		return nil, errEpochFailed
+	}
+	if m.block > 0 && epoch >= m.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return m.mockBeacon.ProposerAssignments(ctx, epoch)
+}
+
+func newRangeRewarder(client BeaconAPI) *Rewarder {
+	return NewRewarder(client, nil, Options{
+		ELRewardSource: mockELSource{},
+		Components:     ComponentAttestations | ComponentBlockRewards,
+		Pipeline:       3,
+	})
+}
+
+func TestGetRewardsForEpochRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end uint64
+		fail       uint64
+	}{
+		{"single epoch", 4, 4, 0},
+		{"failed epoch", 1, 7, 4},
+		{"failed first epoch", 1, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &rangeBeacon{mockBeacon: &mockBeacon{slotsPerEpoch: 4}, fail: tt.fail}
+
+			next := tt.start
+			for res := range newRangeRewarder(client).GetRewardsForEpochRange(context.Background(), tt.start, tt.end) {
+				if res.Epoch != next {
+					t.Fatalf("received epoch %v, expected %v", res.Epoch, next)
+				}
+				next++
+
+				if res.Epoch == tt.fail {
+					if !errors.Is(res.Err, errEpochFailed) || res.EpochRewards != nil {
+						t.Errorf("epoch %v: expected the failure, got %v", res.Epoch, res.Err)
+					}
+					continue
+				}
+				if res.Err != nil {
+					t.Errorf("epoch %v: %v", res.Epoch, res.Err)
+				} else if res.Rewards[100+res.Epoch*4] == nil {
+					t.Errorf("epoch %v: income of the first proposer missing", res.Epoch)
+				}
+			}
+			if next != tt.end+1 {
+				t.Errorf("range ended before epoch %v", next)
+			}
+		})
+	}
+}
+
+func TestGetRewardsForEpochRangeCancel(t *testing.T) {
+	client := &rangeBeacon{mockBeacon: &mockBeacon{slotsPerEpoch: 4}, block: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := newRangeRewarder(client).GetRewardsForEpochRange(ctx, 1, 100)
+	for epoch := uint64(1); epoch < 3; epoch++ {
+		res := <-results
+		if res.Epoch != epoch || res.Err != nil {
+			t.Fatalf("epoch %v: %+v", epoch, res)
+		}
+	}
+
+	// the computations of epoch 3 and the following pipelined epochs are pending
+	cancel()
+	closed := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second * 5):
+		t.Fatal("channel not closed after cancellation")
+	}
+
+	if running := atomic.LoadInt32(&client.running); running != 0 {
+		t.Errorf("%v computations still running after the channel was closed", running)
+	}
+}
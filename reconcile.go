@@ -0,0 +1,245 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// Reconciliation compares the balance change of a validator over an epoch range with the computed income.
+type Reconciliation struct {
+	Validator    uint64
+	StartBalance uint64 // gwei, at the last slot before the range
+	EndBalance   uint64 // gwei, at the last slot of the range
+	Income       int64  // computed CL income in gwei
+	Deposits     uint64 // gwei
+	Withdrawals  uint64 // gwei
+	// Discrepancy is the actual minus the expected balance change in gwei.
+	Discrepancy  int64
+	Explanations []string
+}
+
+// ExpectedBalance is the end balance implied by the start balance, the income, deposits and withdrawals.
+func (rec *Reconciliation) ExpectedBalance() int64 {
+	return int64(rec.StartBalance) + rec.Income + int64(rec.Deposits) - int64(rec.Withdrawals)
+}
+
+// Reconcile compares the balances of the validators before and after the epochs from start to end
+// (inclusive) with their computed CL income, deposits and withdrawals.
+//
+// Attestation rewards of an epoch are applied to the balances two epoch transitions later, while block
+// and sync committee rewards are applied immediately. The balances are therefore compared with the block
+// and sync committee income of the range and the attestation income of the range shifted by two epochs.
+// Likewise the correlation slashing penalties are taken from the range shifted by one epoch.
+//
+// Deposits are only accounted for up to the electra fork. Since electra they are applied from the pending
+// deposits queue, like consolidations, and are reported as an explanation of the discrepancy instead.
+func (r *Rewarder) Reconcile(ctx context.Context, validators []uint64, startEpoch, endEpoch uint64) ([]*Reconciliation, error) {
+	if endEpoch < startEpoch {
+		return nil, fmt.Errorf("invalid epoch range %v - %v", startEpoch, endEpoch)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ids := make([]string, len(validators))
+	for i, v := range validators {
+		ids[i] = strconv.FormatUint(v, 10)
+	}
+
+	results := make(map[uint64]*Reconciliation, len(validators))
+	for _, v := range validators {
+		results[v] = &Reconciliation{Validator: v}
+	}
+
+	opts := r.opts
+	opts.Validators = ids
+	opts.ELRewardSource = r.el
+
+	opts.Components = ComponentSyncCommittee | ComponentBlockRewards | ComponentWithdrawals | ComponentSlashings
+	slotsPerEpoch := uint64(0)
+	// the blocks are fetched for the withdrawals, slashings and sync committee participation anyway
+	var depositSlots []*SlotReward
+	for res := range NewRewarder(r.client, nil, opts).GetRewardsForEpochRange(ctx, startEpoch, endEpoch) {
+		if res.Err != nil {
+			return nil, fmt.Errorf("error computing income of epoch %v: %w", res.Epoch, res.Err)
+		}
+		slotsPerEpoch = uint64(len(res.Slots))
+		for _, slot := range res.Slots {
+			if len(slot.Deposits) > 0 {
+				depositSlots = append(depositSlots, slot)
+			}
+		}
+		for v, income := range res.Rewards {
+			if rec := results[v]; rec != nil {
+				rec.Income += income.TotalClRewards()
+				rec.Withdrawals += income.WithdrawalsAmount
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if endEpoch >= 2 {
+		attStart := startEpoch
+		if attStart < 2 {
+			attStart = 2
+		}
+		opts.Components = ComponentAttestations
+		for res := range NewRewarder(r.client, nil, opts).GetRewardsForEpochRange(ctx, attStart-2, endEpoch-2) {
+			if res.Err != nil {
+				return nil, fmt.Errorf("error computing attestation income of epoch %v: %w", res.Epoch, res.Err)
+			}
+			for v, income := range res.Rewards {
+				if rec := results[v]; rec != nil {
+					rec.Income += income.TotalClRewards()
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	err := r.shiftCorrelationPenalties(ctx, startEpoch, endEpoch, slotsPerEpoch, results)
+	if err != nil {
+		return nil, err
+	}
+
+	// since electra deposits are queued and applied to the balances epochs after their inclusion
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	electra := false
+	electraSlot := ^uint64(0)
+	if electraEpoch, found := spec.Uint64("ELECTRA_FORK_EPOCH"); found && electraEpoch <= endEpoch {
+		electra = true
+		electraSlot = electraEpoch * slotsPerEpoch
+	}
+
+	startState := "genesis"
+	if startEpoch > 0 {
+		startState = strconv.FormatUint(startEpoch*slotsPerEpoch-1, 10)
+	}
+	endState := strconv.FormatUint((endEpoch+1)*slotsPerEpoch-1, 10)
+
+	start, err := r.client.Validators(ctx, startState, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.client.Validators(ctx, endState, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	startValidators := make(map[uint64]*types.ValidatorContainer)
+	pubkeys := make(map[string]uint64)
+	for _, v := range start.Data {
+		startValidators[v.Index] = v
+	}
+	for _, v := range end.Data {
+		pubkeys[strings.ToLower(v.Pubkey)] = v.Index
+	}
+
+	for _, slot := range depositSlots {
+		if slot.Slot >= electraSlot {
+			continue
+		}
+		for _, d := range slot.Deposits {
+			index, found := pubkeys[strings.ToLower(d.Pubkey)]
+			if !found {
+				continue
+			}
+			if rec := results[index]; rec != nil {
+				rec.Deposits += d.Amount
+			}
+		}
+	}
+
+	for _, v := range end.Data {
+		rec := results[v.Index]
+		if rec == nil {
+			continue
+		}
+		rec.EndBalance = v.Balance
+
+		s := startValidators[v.Index]
+		if s == nil {
+			rec.Explanations = append(rec.Explanations, "validator did not exist before the range")
+		} else {
+			rec.StartBalance = s.Balance
+			if s.EffectiveBalance != v.EffectiveBalance {
+				rec.Explanations = append(rec.Explanations, fmt.Sprintf("effective balance changed from %d to %d gwei", s.EffectiveBalance, v.EffectiveBalance))
+			}
+			if v.Slashed && !s.Slashed {
+				rec.Explanations = append(rec.Explanations, "validator was slashed within the range")
+			} else if v.Slashed {
+				rec.Explanations = append(rec.Explanations, "validator is slashed, the correlation penalty is an approximation")
+			}
+		}
+
+		if v.ActivationEpoch > startEpoch && v.ActivationEpoch <= endEpoch+1 {
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("validator was activated in epoch %d", v.ActivationEpoch))
+		}
+		if v.ExitEpoch > startEpoch && v.ExitEpoch <= endEpoch+1 {
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("validator exited in epoch %d", v.ExitEpoch))
+		}
+		if rec.Deposits > 0 {
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("deposits of %d gwei were processed", rec.Deposits))
+		}
+		if rec.Withdrawals > 0 {
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("withdrawals of %d gwei were processed", rec.Withdrawals))
+		}
+
+		rec.Discrepancy = int64(rec.EndBalance) - rec.ExpectedBalance()
+		if rec.Discrepancy != 0 && electra {
+			rec.Explanations = append(rec.Explanations, "deposits and consolidations are applied from pending queues since electra and not accounted for")
+		}
+		if rec.Discrepancy != 0 && len(rec.Explanations) == 0 {
+			rec.Explanations = append(rec.Explanations, "unexplained difference between balance change and computed income")
+		}
+	}
+
+	reconciliations := make([]*Reconciliation, 0, len(results))
+	for _, rec := range results {
+		reconciliations = append(reconciliations, rec)
+	}
+	sort.Slice(reconciliations, func(i, j int) bool {
+		return reconciliations[i].Validator < reconciliations[j].Validator
+	})
+	return reconciliations, nil
+}
+
+// shiftCorrelationPenalties moves the correlation penalties included in the income of the epochs from start
+// to end to the epochs from start-1 to end-1. The penalties of an epoch are applied by its epoch transition,
+// which is after the last slot of end and before the first slot of start for start-1.
+func (r *Rewarder) shiftCorrelationPenalties(ctx context.Context, startEpoch, endEpoch, slotsPerEpoch uint64, results map[uint64]*Reconciliation) error {
+	penalties, err := r.correlationPenalties(ctx, endEpoch, slotsPerEpoch)
+	if err != nil {
+		return err
+	}
+	for v, penalty := range penalties {
+		if rec := results[v]; rec != nil {
+			rec.Income += int64(penalty)
+		}
+	}
+
+	if startEpoch == 0 {
+		return nil
+	}
+	penalties, err = r.correlationPenalties(ctx, startEpoch-1, slotsPerEpoch)
+	if err != nil {
+		return err
+	}
+	for v, penalty := range penalties {
+		if rec := results[v]; rec != nil {
+			rec.Income -= int64(penalty)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// verifyChain checks that the blocks the epoch was computed from belong to a single chain that is still
+// canonical.
+//
+// If the blocks of the epoch were fetched, the dependent root of the proposer duties must be the block at
+// the epoch's decision slot and every block of the epoch must descend from its predecessor. Afterwards the
+// proposer duties are requested again to check that the dependent root did not change and the last block
+// of the epoch is checked to still be canonical.
+func (r *Rewarder) verifyChain(ctx context.Context, epoch uint64, dependentRoot string, slots []*SlotReward) error {
+	parent := ""
+	last := ""
+	for _, slot := range slots {
+		if slot.BlockRoot == "" {
+			continue
+		}
+		if r.computes(slotComponents) && parent != "" && slot.parentRoot != parent {
+			return fmt.Errorf("%w: parent of block %v at slot %v is %v instead of %v", types.ErrChainReorg, slot.BlockRoot, slot.Slot, slot.parentRoot, parent)
+		}
+		parent = slot.BlockRoot
+		last = slot.BlockRoot
+	}
+
+	if r.computes(slotComponents) && last != "" {
+		root, err := r.decisionRoot(ctx, epoch, uint64(len(slots)))
+		if err != nil {
+			return err
+		}
+		if root != dependentRoot {
+			return fmt.Errorf("%w: dependent root of epoch %v is %v instead of %v", types.ErrChainReorg, epoch, dependentRoot, root)
+		}
+	}
+
+	proposerAssignments, err := r.client.ProposerAssignments(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	if proposerAssignments.DependentRoot != dependentRoot {
+		return fmt.Errorf("%w: dependent root of epoch %v changed from %v to %v", types.ErrChainReorg, epoch, dependentRoot, proposerAssignments.DependentRoot)
+	}
+
+	if last == "" {
+		return nil
+	}
+	header, err := r.client.BlockHeader(ctx, last)
+	if err != nil {
+		return reorgError(err, nil)
+	}
+	if !header.Data.Canonical {
+		return fmt.Errorf("%w: block %v is no longer canonical", types.ErrChainReorg, last)
+	}
+	return nil
+}
+
+// decisionRoot returns the root of the block the proposer duties of the epoch depend on, the last block
+// before the epoch. Since fulu the proposers are determined one epoch ahead (EIP-7917), so the duties depend
+// on the last block before the previous epoch.
+func (r *Rewarder) decisionRoot(ctx context.Context, epoch, slotsPerEpoch uint64) (string, error) {
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		return "", err
+	}
+	lookahead := uint64(1)
+	if fuluEpoch, found := spec.Uint64("FULU_FORK_EPOCH"); found && fuluEpoch <= epoch {
+		lookahead += slotsPerEpoch
+	}
+
+	// the duties of the first epochs depend on the genesis block
+	slot := uint64(0)
+	if epoch*slotsPerEpoch >= lookahead {
+		slot = epoch*slotsPerEpoch - lookahead
+	}
+	for {
+		header, err := r.client.BlockHeader(ctx, strconv.FormatUint(slot, 10))
+		if err == types.ErrBlockNotFound && slot > 0 {
+			slot--
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return header.Data.Root, nil
+	}
+}
+
+// reorgError converts the error of a request by block root. A block that disappeared after its header
+// was fetched was reorged out of the chain.
+func reorgError(err error, slot *SlotReward) error {
+	if err != types.ErrBlockNotFound {
+		return err
+	}
+	if slot == nil {
+		return fmt.Errorf("%w: block not found", types.ErrChainReorg)
+	}
+	return fmt.Errorf("%w: block %v at slot %v not found", types.ErrChainReorg, slot.BlockRoot, slot.Slot)
+}
@@ -0,0 +1,62 @@
+package ethrewards
+
+import (
+	"math/big"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// EpochRewards is the income of the validators for an epoch together with the per slot details it
+// was computed from.
+type EpochRewards struct {
+	Epoch   uint64
+	Rewards map[uint64]*types.ValidatorEpochIncome
+	Slots   []*SlotReward
+	// SyncCommittee are the indices of the sync committee members of the epoch by committee position,
+	// nil pre altair or if the sync committee rewards were not computed.
+	SyncCommittee []uint64
+	// Finalized reports whether the epoch and the epoch its attestations are included in were finalized
+	// before the computation. The income of epochs that are not finalized may still change.
+	Finalized bool
+	// Optimistic reports whether any of the data the income was computed from was served while the beacon
+	// node's execution client was not synced (execution_optimistic).
+	Optimistic bool
+}
+
+// SlotReward holds the rewards of a single slot. Proposer related fields are only set if the proposer
+// is part of the computed validator set.
+type SlotReward struct {
+	Slot     uint64
+	Proposer uint64
+	// BlockRoot is the root of the slot's block, empty if the slot was missed or its block not fetched
+	BlockRoot       string
+	parentRoot      string
+	ExecBlockNumber uint64   // 0 if the slot is pre merge
+	ELFeeWei        *big.Int // nil if the tx fees were not computed
+	BlockReward     *types.BlockRewardsContainer
+	// Slashings are the validators slashed in the slot's block.
+	Slashings []*Slashing
+	// Deposits are the deposits included in the slot's block, nil if the block was not fetched.
+	Deposits []*types.Deposit
+	// Withdrawals are the withdrawals of the computed validators processed in the slot.
+	Withdrawals []*types.Withdrawal
+	// SyncCommitteeRewards are the rewards of the sync committee members for the slot.
+	SyncCommitteeRewards []*types.SyncCommitteeRewardsContainer
+	// SyncCommitteeParticipation are the participation bits of the block's sync aggregate by committee
+	// position, nil if the slot was missed or is pre altair.
+	SyncCommitteeParticipation []bool
+	Missed                     bool
+	// Optimistic reports whether any of the slot's data was served optimistically
+	Optimistic bool
+	// EstimatedClReward and EstimatedELFeeWei are the rewards the proposer of a missed slot forwent
+	EstimatedClReward uint64
+	EstimatedELFeeWei *big.Int
+}
+
+// Slashing is a validator slashed by a proposer or attester slashing included in a block.
+type Slashing struct {
+	Validator           uint64
+	Penalty             uint64 // initial slashing penalty in gwei
+	WhistleblowerReward uint64 // gwei, including the proposer reward
+	ProposerReward      uint64 // gwei
+}
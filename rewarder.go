@@ -0,0 +1,564 @@
+package ethrewards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/eth-rewards/elrewards"
+	"github.com/gobitfly/eth-rewards/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Component selects a part of the validator income that is computed for an epoch.
+type Component uint
+
+const (
+	ComponentAttestations  Component = 1 << iota // attestation source, target & head rewards, attestation and inactivity penalties
+	ComponentSyncCommittee                       // sync committee rewards, penalties and missed participation
+	ComponentBlockRewards                        // CL proposer rewards
+	ComponentExecutionFees                       // EL tx fee rewards
+	ComponentWithdrawals                         // withdrawals from the execution payloads (not part of the CL income)
+	ComponentSlashings                           // slashing penalties and whistleblower rewards
+	// ComponentMissedAttestationRewards compares the attestation rewards with the ideal rewards,
+	// requires ComponentAttestations
+	ComponentMissedAttestationRewards
+	// ComponentMissedProposalRewards estimates the rewards of missed proposals from the neighboring
+	// blocks, the EL part requires ComponentExecutionFees
+	ComponentMissedProposalRewards
+
+	// slotComponents are the components requiring the blocks of the epoch
+	slotComponents = ComponentSyncCommittee | ComponentBlockRewards | ComponentExecutionFees | ComponentWithdrawals |
+		ComponentSlashings | ComponentMissedProposalRewards
+
+	ComponentAll = ComponentAttestations | ComponentSyncCommittee | ComponentBlockRewards | ComponentExecutionFees |
+		ComponentWithdrawals | ComponentSlashings | ComponentMissedAttestationRewards | ComponentMissedProposalRewards
+)
+
+// FinalityMode controls how epochs that are not finalized yet are handled.
+type FinalityMode int
+
+const (
+	FinalityFlag   FinalityMode = iota // compute the epoch and report its status in EpochRewards.Finalized
+	FinalityRefuse                     // fail with types.ErrEpochNotFinalized
+	FinalityWait                       // wait until the epoch is finalized
+)
+
+type Options struct {
+	// Concurrency is the maximum number of slots (and thus beacon & EL requests) processed in parallel.
+	Concurrency int
+	// Pipeline is the number of epochs computed concurrently by GetRewardsForEpochRange. Each of
+	// them uses up to Concurrency parallel requests.
+	Pipeline int
+	// ELRewardSource calculates the tx fee rewards of proposed blocks. If nil, the rewards are
+	// calculated from the tx receipts of the EL client passed to NewRewarder.
+	ELRewardSource elrewards.ELRewardSource
+	// ELTimeout is the overall timeout for calculating the tx fee reward of one block. Only used by
+	// the default ELRewardSource.
+	ELTimeout time.Duration
+	// ELRetry controls the retries of the EL receipts requests. Only used by the default ELRewardSource.
+	ELRetry elrewards.RetryPolicy
+	Logger  logrus.FieldLogger
+	// ValidatorFilter restricts the returned income to the validators it returns true for.
+	// Blocks of filtered proposers are not fetched from the EL.
+	ValidatorFilter func(validatorIndex uint64) bool
+	// Validators restricts the computation to the given validator indices or 0x prefixed pubkeys.
+	// Only their rewards are requested from the beacon node and only blocks they proposed are
+	// fetched. If empty, the income of all validators is computed.
+	Validators []string
+	// Components selects which parts of the income are computed, defaults to ComponentAll.
+	Components Component
+	// ReorgRetries is the number of times the computation of an epoch is restarted if the chain
+	// reorganized while it was computed. If exhausted, types.ErrChainReorg is returned.
+	ReorgRetries int
+	// Finality controls how epochs that are not finalized yet are handled, defaults to FinalityFlag.
+	Finality FinalityMode
+	// FinalityPollInterval is the interval the finality checkpoints are polled at with FinalityWait.
+	FinalityPollInterval time.Duration
+	// RejectOptimistic fails the computation of an epoch with types.ErrExecutionOptimistic if any of the
+	// data it depends on was served while the beacon node's execution client was not synced.
+	RejectOptimistic bool
+}
+
+func DefaultOptions() Options {
+	elConfig := elrewards.DefaultConfig()
+	return Options{
+		Concurrency: 32,
+		Pipeline:    4,
+		ELTimeout:   elConfig.Timeout,
+		ELRetry:     elConfig.Retry,
+		Logger:      logrus.StandardLogger(),
+		Components:  ComponentAll,
+
+		FinalityPollInterval: time.Minute,
+	}
+}
+
+func (o Options) withDefaults() Options {
+	d := DefaultOptions()
+	if o.Concurrency <= 0 {
+		o.Concurrency = d.Concurrency
+	}
+	if o.Pipeline <= 0 {
+		o.Pipeline = d.Pipeline
+	}
+	if o.ELTimeout <= 0 {
+		o.ELTimeout = d.ELTimeout
+	}
+	if o.Logger == nil {
+		o.Logger = d.Logger
+	}
+	if o.Components == 0 {
+		o.Components = d.Components
+	}
+	if o.FinalityPollInterval <= 0 {
+		o.FinalityPollInterval = d.FinalityPollInterval
+	}
+	return o
+}
+
+type Rewarder struct {
+	client BeaconAPI
+	el     elrewards.ELRewardSource
+	opts   Options
+	log    logrus.FieldLogger
+
+	validatorIndices map[uint64]bool
+	validatorPubkeys map[string]bool
+
+	specMux sync.Mutex
+	spec    *types.SpecApiResponse
+}
+
+// NewRewarder creates a Rewarder. The EL client is owned by the caller and may be nil if
+// opts.ELRewardSource is set.
+func NewRewarder(client BeaconAPI, elClient *elrewards.Client, opts Options) *Rewarder {
+	opts = opts.withDefaults()
+	el := opts.ELRewardSource
+	if el == nil {
+		el = elrewards.NewReceiptRewardSource(elClient, elrewards.Config{
+			Timeout: opts.ELTimeout,
+			Retry:   opts.ELRetry,
+			Logger:  opts.Logger,
+		})
+	}
+	r := &Rewarder{
+		client: client,
+		el:     el,
+		opts:   opts,
+		log:    opts.Logger,
+	}
+	if len(opts.Validators) > 0 {
+		r.validatorIndices = make(map[uint64]bool)
+		r.validatorPubkeys = make(map[string]bool)
+		for _, v := range opts.Validators {
+			if index, err := strconv.ParseUint(v, 10, 64); err == nil {
+				r.validatorIndices[index] = true
+			} else {
+				r.validatorPubkeys[strings.ToLower(v)] = true
+			}
+		}
+	}
+	return r
+}
+
+func (r *Rewarder) computes(c Component) bool {
+	return r.opts.Components&c != 0
+}
+
+func (r *Rewarder) includes(validator uint64) bool {
+	return r.opts.ValidatorFilter == nil || r.opts.ValidatorFilter(validator)
+}
+
+// includesProposer reports whether the proposer is part of the configured validator set.
+func (r *Rewarder) includesProposer(pa *types.EpochProposerAssignmentsContainer) bool {
+	if !r.includes(uint64(pa.ValidatorIndex)) {
+		return false
+	}
+	if len(r.opts.Validators) == 0 {
+		return true
+	}
+	return r.validatorIndices[uint64(pa.ValidatorIndex)] || r.validatorPubkeys[strings.ToLower(pa.Pubkey)]
+}
+
+// inValidatorSet reports whether the validator is part of the configured validator set. Validators
+// configured by pubkey are matched via the income already computed for them.
+func (r *Rewarder) inValidatorSet(validator uint64, rewards map[uint64]*types.ValidatorEpochIncome) bool {
+	return len(r.opts.Validators) == 0 || r.validatorIndices[validator] || rewards[validator] != nil
+}
+
+// incomeMap is the income of all validators of an epoch, guarded for concurrent updates.
+type incomeMap struct {
+	sync.Mutex
+	m map[uint64]*types.ValidatorEpochIncome
+	// optimistic is set if epoch wide data was served while the node's execution client was not synced
+	optimistic bool
+}
+
+// get returns the income of the validator, creating it if necessary. The caller must hold the lock.
+func (im *incomeMap) get(validator uint64) *types.ValidatorEpochIncome {
+	if im.m[validator] == nil {
+		im.m[validator] = &types.ValidatorEpochIncome{}
+	}
+	return im.m[validator]
+}
+
+func (r *Rewarder) GetRewardsForEpoch(ctx context.Context, epoch uint64) (map[uint64]*types.ValidatorEpochIncome, error) {
+	res, err := r.GetEpochRewards(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	return res.Rewards, nil
+}
+
+// GetEpochRewards computes the income of the validators for the epoch together with the per slot breakdown.
+//
+// The computation is pinned to the chain the epoch's proposer duties depend on. If the chain reorganizes
+// while the epoch is computed, the computation is restarted up to Options.ReorgRetries times before
+// failing with types.ErrChainReorg.
+func (r *Rewarder) GetEpochRewards(ctx context.Context, epoch uint64) (*EpochRewards, error) {
+	finality, err := r.finality(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := r.epochRewards(ctx, epoch)
+		if err == nil {
+			res.Finalized = finality.finalized
+			res.Optimistic = res.Optimistic || finality.optimistic
+			if r.opts.RejectOptimistic && res.Optimistic {
+				return nil, fmt.Errorf("%w: epoch %v", types.ErrExecutionOptimistic, epoch)
+			}
+		}
+		if !errors.Is(err, types.ErrChainReorg) || attempt >= r.opts.ReorgRetries {
+			return res, err
+		}
+		r.log.Warnf("restarting computation of epoch %v: %v", epoch, err)
+	}
+}
+
+func (r *Rewarder) epochRewards(ctx context.Context, epoch uint64) (*EpochRewards, error) {
+	proposerAssignments, err := r.client.ProposerAssignments(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	slotsPerEpoch := uint64(len(proposerAssignments.Data))
+
+	startSlot := epoch * slotsPerEpoch
+	endSlot := startSlot + slotsPerEpoch - 1
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(r.opts.Concurrency)
+
+	slotsToProposer := make(map[uint64]*types.EpochProposerAssignmentsContainer)
+	for _, pa := range proposerAssignments.Data {
+		slotsToProposer[uint64(pa.Slot)] = pa
+	}
+
+	rewards := &incomeMap{m: make(map[uint64]*types.ValidatorEpochIncome)}
+	slots := make([]*SlotReward, slotsPerEpoch)
+
+	for i := startSlot; i <= endSlot; i++ {
+		i := i
+
+		g.Go(func() error {
+			proposer, found := slotsToProposer[i]
+			if !found {
+				return fmt.Errorf("assigned proposer for slot %v not found", i)
+			}
+			slot := &SlotReward{
+				Slot:     i,
+				Proposer: uint64(proposer.ValidatorIndex),
+			}
+			slots[i-startSlot] = slot
+			return r.slotRewards(gCtx, slot, r.includesProposer(proposer), rewards)
+		})
+	}
+
+	if r.computes(ComponentAttestations) {
+		g.Go(func() error {
+			return r.attestationRewards(gCtx, epoch, slotsPerEpoch, rewards)
+		})
+	}
+
+	var syncCommittee []uint64
+	if r.computes(ComponentSyncCommittee) {
+		g.Go(func() error {
+			var err error
+			syncCommittee, err = r.syncCommittee(gCtx, epoch, slotsPerEpoch)
+			return err
+		})
+	}
+
+	var correlationPenalties map[uint64]uint64
+	if r.computes(ComponentSlashings) {
+		g.Go(func() error {
+			var err error
+			correlationPenalties, err = r.correlationPenalties(gCtx, epoch, slotsPerEpoch)
+			return err
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.verifyChain(ctx, epoch, proposerAssignments.DependentRoot, slots)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.computes(ComponentSyncCommittee) {
+		r.syncCommitteeParticipation(slots, syncCommittee, rewards.m)
+	}
+	if r.computes(ComponentWithdrawals) {
+		r.withdrawals(slots, rewards.m)
+	}
+	if r.computes(ComponentSlashings) {
+		r.slashings(slots, slotsToProposer, correlationPenalties, rewards.m)
+	}
+	if r.computes(ComponentMissedProposalRewards) {
+		err = r.missedProposalRewards(ctx, slots, slotsToProposer, rewards.m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for validator, income := range rewards.m {
+		if !r.includes(validator) {
+			delete(rewards.m, validator)
+			continue
+		}
+		sort.Slice(income.TxFeeRewards, func(i, j int) bool {
+			return income.TxFeeRewards[i].Slot < income.TxFeeRewards[j].Slot
+		})
+	}
+
+	optimistic := proposerAssignments.ExecutionOptimistic || rewards.optimistic
+	for _, slot := range slots {
+		optimistic = optimistic || slot.Optimistic
+	}
+
+	return &EpochRewards{
+		Epoch:         epoch,
+		Rewards:       rewards.m,
+		Slots:         slots,
+		SyncCommittee: syncCommittee,
+		Optimistic:    optimistic,
+	}, nil
+}
+
+// slotRewards computes the rewards of the slot. Everything after the header is requested by the block root,
+// so the data of the slot belongs to the block whose ancestry is verified by verifyChain.
+func (r *Rewarder) slotRewards(ctx context.Context, slot *SlotReward, includeProposer bool, rewards *incomeMap) error {
+	if !includeProposer && !r.computes(slotComponents) {
+		return nil
+	}
+
+	header, err := r.client.BlockHeader(ctx, strconv.FormatUint(slot.Slot, 10))
+	if err != nil {
+		if err != types.ErrBlockNotFound {
+			return err
+		}
+		slot.Missed = true
+		if includeProposer {
+			rewards.Lock()
+			rewards.get(slot.Proposer).ProposalsMissed += 1
+			rewards.Unlock()
+		}
+		return nil
+	}
+	slot.BlockRoot = header.Data.Root
+	slot.parentRoot = header.Data.ParentRoot
+	slot.Optimistic = header.ExecutionOptimistic
+
+	if includeProposer || r.computes(ComponentWithdrawals|ComponentSlashings|ComponentSyncCommittee) {
+		block, err := r.client.Block(ctx, slot.BlockRoot)
+		if err != nil {
+			return reorgError(err, slot)
+		}
+		slot.Optimistic = slot.Optimistic || block.ExecutionOptimistic
+		slot.Deposits = block.Data.Deposits
+
+		if includeProposer {
+			err := r.proposerRewards(ctx, slot, block, rewards)
+			if err != nil {
+				return err
+			}
+		}
+
+		if r.computes(ComponentWithdrawals) && block.Data.ExecutionPayload != nil {
+			slot.Withdrawals = block.Data.ExecutionPayload.Withdrawals
+		}
+
+		if r.computes(ComponentSlashings) {
+			slot.Slashings, err = r.blockSlashings(ctx, slot.Slot, block)
+			if err != nil {
+				return err
+			}
+		}
+
+		if r.computes(ComponentSyncCommittee) && block.Data.SyncCommitteeBits != nil {
+			slot.SyncCommitteeParticipation = make([]bool, len(block.Data.SyncCommitteeBits)*8)
+			for i := range slot.SyncCommitteeParticipation {
+				slot.SyncCommitteeParticipation[i] = block.Data.SyncCommitteeParticipated(i)
+			}
+		}
+	}
+
+	if !r.computes(ComponentSyncCommittee) {
+		return nil
+	}
+
+	syncRewards, err := r.client.SyncCommitteeRewards(ctx, slot.BlockRoot, r.opts.Validators)
+	if err != nil && err != types.ErrSlotPreSyncCommittees {
+		return reorgError(err, slot)
+	}
+
+	rewards.Lock()
+	defer rewards.Unlock()
+	if syncRewards != nil {
+		slot.Optimistic = slot.Optimistic || syncRewards.ExecutionOptimistic
+		slot.SyncCommitteeRewards = syncRewards.Data
+		for _, sr := range syncRewards.Data {
+			if sr.Reward > 0 {
+				rewards.get(sr.ValidatorIndex).SyncCommitteeReward += uint64(sr.Reward)
+			} else {
+				rewards.get(sr.ValidatorIndex).SyncCommitteePenalty += uint64(sr.Reward * -1)
+			}
+		}
+	}
+	return nil
+}
+
+// withdrawals attributes the withdrawals of the epoch's execution payloads to the withdrawn validators. If
+// the computation is restricted to a validator set, pubkeys are matched via the income already present.
+func (r *Rewarder) withdrawals(slots []*SlotReward, rewards map[uint64]*types.ValidatorEpochIncome) {
+	for _, slot := range slots {
+		withdrawals := slot.Withdrawals
+		slot.Withdrawals = nil
+		for _, w := range withdrawals {
+			if !r.inValidatorSet(w.ValidatorIndex, rewards) {
+				continue
+			}
+			slot.Withdrawals = append(slot.Withdrawals, w)
+			if rewards[w.ValidatorIndex] == nil {
+				rewards[w.ValidatorIndex] = &types.ValidatorEpochIncome{}
+			}
+			rewards[w.ValidatorIndex].WithdrawalsAmount += w.Amount
+			rewards[w.ValidatorIndex].WithdrawalsCount += 1
+		}
+	}
+}
+
+// proposerRewards adds the EL and CL rewards of the block proposed in the slot.
+func (r *Rewarder) proposerRewards(ctx context.Context, slot *SlotReward, block *types.BeaconBlockApiResponse, rewards *incomeMap) error {
+	if r.computes(ComponentExecutionFees) && block.Data.ExecutionPayload != nil {
+		execBlockNumber := block.Data.ExecutionPayload.BlockNumber
+		txFeeIncome, err := r.el.ELRewardForBlockHash(ctx, block.Data.ExecutionPayload.BlockHash)
+		if err != nil {
+			return err
+		}
+		slot.ExecBlockNumber = execBlockNumber
+		slot.ELFeeWei = txFeeIncome
+
+		rewards.Lock()
+		income := rewards.get(slot.Proposer)
+		income.TxFeeRewards = append(income.TxFeeRewards, &types.BlockTxFeeReward{
+			Slot:            slot.Slot,
+			ExecBlockNumber: execBlockNumber,
+			FeeRewardWei:    txFeeIncome.Bytes(),
+		})
+		totalTxFee := new(big.Int).SetBytes(income.TxFeeRewardWei)
+		income.TxFeeRewardWei = totalTxFee.Add(totalTxFee, txFeeIncome).Bytes()
+		rewards.Unlock()
+	}
+
+	if r.computes(ComponentBlockRewards) {
+		blockRewards, err := r.client.BlockRewards(ctx, slot.BlockRoot)
+		if err != nil {
+			return reorgError(err, slot)
+		}
+		slot.BlockReward = &blockRewards.Data
+		slot.Optimistic = slot.Optimistic || blockRewards.ExecutionOptimistic
+
+		rewards.Lock()
+		income := rewards.get(blockRewards.Data.ProposerIndex)
+		income.ProposerAttestationInclusionReward += blockRewards.Data.Attestations
+		income.ProposerSlashingInclusionReward += blockRewards.Data.AttesterSlashings + blockRewards.Data.ProposerSlashings
+		income.ProposerSyncInclusionReward += blockRewards.Data.SyncAggregate
+		rewards.Unlock()
+	}
+	return nil
+}
+
+func (r *Rewarder) attestationRewards(ctx context.Context, epoch, slotsPerEpoch uint64, rewards *incomeMap) error {
+	ar, err := r.client.AttestationRewards(ctx, epoch, r.opts.Validators)
+	if err != nil {
+		return err
+	}
+
+	var ideals map[uint64]*types.IdealAttestationRewardContainer
+	if r.computes(ComponentMissedAttestationRewards) {
+		ideals, err = r.idealAttestationRewards(ctx, epoch, slotsPerEpoch, ar)
+		if err != nil {
+			return err
+		}
+	}
+
+	rewards.Lock()
+	defer rewards.Unlock()
+	rewards.optimistic = rewards.optimistic || ar.ExecutionOptimistic
+	for _, ar := range ar.Data.TotalRewards {
+		income := rewards.get(ar.ValidatorIndex)
+
+		if ar.Head >= 0 {
+			income.AttestationHeadReward = uint64(ar.Head)
+		} else {
+			return fmt.Errorf("retrieved negative attestation head reward for validator %v: %v", ar.ValidatorIndex, ar.Head)
+		}
+
+		if ar.Source > 0 {
+			income.AttestationSourceReward = uint64(ar.Source)
+		} else {
+			income.AttestationSourcePenalty = uint64(ar.Source * -1)
+		}
+
+		if ar.Target > 0 {
+			income.AttestationTargetReward = uint64(ar.Target)
+		} else {
+			income.AttestationTargetPenalty = uint64(ar.Target * -1)
+		}
+
+		if ar.InclusionDelay <= 0 {
+			income.FinalityDelayPenalty = uint64(ar.InclusionDelay * -1)
+		} else {
+			return fmt.Errorf("retrieved positive inclusion delay penalty for validator %v: %v", ar.ValidatorIndex, ar.InclusionDelay)
+		}
+
+		if ar.Inactivity <= 0 {
+			income.InactivityPenalty = uint64(ar.Inactivity * -1)
+		} else {
+			return fmt.Errorf("retrieved positive inactivity penalty for validator %v: %v", ar.ValidatorIndex, ar.Inactivity)
+		}
+
+		if ideal := ideals[ar.ValidatorIndex]; ideal != nil {
+			income.AttestationHeadMissedReward = missedReward(ideal.Head, ar.Head)
+			income.AttestationSourceMissedReward = missedReward(ideal.Source, ar.Source)
+			income.AttestationTargetMissedReward = missedReward(ideal.Target, ar.Target)
+		}
+	}
+
+	return nil
+}
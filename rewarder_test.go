@@ -0,0 +1,271 @@
+package ethrewards
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// mockBeacon is a BeaconAPI serving a chain of slotsPerEpoch slots per epoch whose block at slot s has the
+// root blockRoot(s) and is proposed by validator 100+s. Proposer duties and headers are decoded from the
+// JSON the beacon API returns.
+type mockBeacon struct {
+	slotsPerEpoch uint64
+	missed        map[uint64]bool
+	// fulu activates fulu at genesis, the proposer duties then depend on the last block before the previous
+	// epoch
+	fulu bool
+
+	mux sync.Mutex
+	// dutiesCalls counts the proposer duties requests, reorgAfter changes the dependent root for all
+	// requests after the given number of calls if > 0.
+	dutiesCalls int
+	reorgAfter  int
+}
+
+func blockRoot(slot uint64) string {
+	return fmt.Sprintf("0x%064x", slot+1)
+}
+
+func (m *mockBeacon) slotOf(blockID string) (uint64, error) {
+	if strings.HasPrefix(blockID, "0x") {
+		root, err := strconv.ParseUint(strings.TrimLeft(blockID[2:], "0"), 16, 64)
+		if err != nil || root == 0 {
+			return 0, fmt.Errorf("invalid block root %v", blockID)
+		}
+		return root - 1, nil
+	}
+	return strconv.ParseUint(blockID, 10, 64)
+}
+
+// parentRoot is the root of the last block before the slot.
+func (m *mockBeacon) parentRoot(slot uint64) string {
+	for s := slot; s > 0; s-- {
+		if !m.missed[s-1] {
+			return blockRoot(s - 1)
+		}
+	}
+	return fmt.Sprintf("0x%064x", 0)
+}
+
+func (m *mockBeacon) block(blockID string) (uint64, error) {
+	slot, err := m.slotOf(blockID)
+	if err != nil {
+		return 0, err
+	}
+	if m.missed[slot] {
+		return 0, types.ErrBlockNotFound
+	}
+	return slot, nil
+}
+
+func (m *mockBeacon) ProposerAssignments(ctx context.Context, epoch uint64) (*types.EpochProposerAssignmentsApiResponse, error) {
+	m.mux.Lock()
+	m.dutiesCalls++
+	dependentRoot := m.parentRoot(epoch * m.slotsPerEpoch)
+	if m.fulu && epoch > 0 {
+		dependentRoot = m.parentRoot((epoch - 1) * m.slotsPerEpoch)
+	}
+	if m.reorgAfter > 0 && m.dutiesCalls > m.reorgAfter {
+		dependentRoot = fmt.Sprintf("0x%064x", 0xdead)
+	}
+	m.mux.Unlock()
+
+	duties := make([]string, m.slotsPerEpoch)
+	for i := range duties {
+		slot := epoch*m.slotsPerEpoch + uint64(i)
+		duties[i] = fmt.Sprintf(`{"pubkey":"0x%096x","validator_index":"%d","slot":"%d"}`, slot, 100+slot, slot)
+	}
+	data := fmt.Sprintf(`{"dependent_root":"%s","execution_optimistic":false,"data":[%s]}`, dependentRoot, strings.Join(duties, ","))
+
+	r := &types.EpochProposerAssignmentsApiResponse{}
+	return r, json.Unmarshal([]byte(data), r)
+}
+
+func (m *mockBeacon) BlockHeader(ctx context.Context, blockID string) (*types.BlockHeaderApiResponse, error) {
+	slot, err := m.block(blockID)
+	if err != nil {
+		return nil, err
+	}
+	data := fmt.Sprintf(`{"execution_optimistic":false,"data":{"root":"%s","canonical":true,"header":{"message":{"slot":"%d","proposer_index":"%d","parent_root":"%s","state_root":"0x%064x"}}}}`,
+		blockRoot(slot), slot, 100+slot, m.parentRoot(slot), slot)
+
+	r := &types.BlockHeaderApiResponse{}
+	return r, json.Unmarshal([]byte(data), r)
+}
+
+func (m *mockBeacon) Block(ctx context.Context, blockID string) (*types.BeaconBlockApiResponse, error) {
+	slot, err := m.block(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BeaconBlockApiResponse{Data: types.BeaconBlockContainer{
+		Slot:          slot,
+		ProposerIndex: 100 + slot,
+		ParentRoot:    m.parentRoot(slot),
+		ExecutionPayload: &types.ExecutionPayload{
+			BlockNumber: 1000 + slot,
+			BlockHash:   fmt.Sprintf("0x%064x", 1000+slot),
+		},
+	}}, nil
+}
+
+func (m *mockBeacon) BlockRewards(ctx context.Context, blockID string) (*types.BlockRewardsApiResponse, error) {
+	slot, err := m.block(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BlockRewardsApiResponse{Data: types.BlockRewardsContainer{
+		ProposerIndex: 100 + slot,
+		Attestations:  20,
+		SyncAggregate: 5,
+		Total:         25,
+	}}, nil
+}
+
+func (m *mockBeacon) SyncCommitteeRewards(ctx context.Context, blockID string, validators []string) (*types.SyncCommitteeRewardsApiResponse, error) {
+	if _, err := m.block(blockID); err != nil {
+		return nil, err
+	}
+	return &types.SyncCommitteeRewardsApiResponse{Data: []*types.SyncCommitteeRewardsContainer{
+		{ValidatorIndex: 1, Reward: 10},
+	}}, nil
+}
+
+func (m *mockBeacon) AttestationRewards(ctx context.Context, epoch uint64, validators []string) (*types.AttestationRewardsApiResponse, error) {
+	r := &types.AttestationRewardsApiResponse{}
+	r.Data.TotalRewards = []*types.TotalAttestationRewardsContainer{
+		{ValidatorIndex: 1, Head: 3, Source: 2, Target: 4},
+	}
+	return r, nil
+}
+
+func (m *mockBeacon) FinalityCheckpoints(ctx context.Context, stateID string) (*types.FinalityCheckpointsApiResponse, error) {
+	r := &types.FinalityCheckpointsApiResponse{}
+	r.Data.Finalized.Epoch = 1000
+	return r, nil
+}
+
+func (m *mockBeacon) Spec(ctx context.Context) (*types.SpecApiResponse, error) {
+	spec := &types.SpecApiResponse{Data: map[string]json.RawMessage{}}
+	if m.fulu {
+		spec.Data["FULU_FORK_EPOCH"] = json.RawMessage(`"0"`)
+	}
+	return spec, nil
+}
+
+func (m *mockBeacon) SyncCommittee(ctx context.Context, stateID string, epoch uint64) (*types.SyncCommitteeApiResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockBeacon) Validators(ctx context.Context, stateID string, ids []string, statuses []string) (*types.ValidatorsApiResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+type mockELSource struct{}
+
+func (mockELSource) ELRewardForBlockHash(ctx context.Context, executionBlockHash string) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func newMockRewarder(client BeaconAPI, reorgRetries int) *Rewarder {
+	return NewRewarder(client, nil, Options{
+		ELRewardSource: mockELSource{},
+		Components:     ComponentAttestations | ComponentSyncCommittee | ComponentBlockRewards | ComponentExecutionFees,
+		ReorgRetries:   reorgRetries,
+	})
+}
+
+func TestGetEpochRewardsMissedSlot(t *testing.T) {
+	client := &mockBeacon{slotsPerEpoch: 4, missed: map[uint64]bool{9: true}}
+
+	res, err := newMockRewarder(client, 0).GetEpochRewards(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Finalized {
+		t.Errorf("epoch not finalized")
+	}
+	for _, slot := range res.Slots {
+		if slot.Missed != (slot.Slot == 9) {
+			t.Errorf("slot %v: missed %v", slot.Slot, slot.Missed)
+		}
+	}
+
+	missed := res.Rewards[109]
+	if missed == nil || missed.ProposalsMissed != 1 || missed.ProposerAttestationInclusionReward != 0 {
+		t.Errorf("proposer of the missed slot: %v", missed)
+	}
+	for _, proposer := range []uint64{108, 110, 111} {
+		income := res.Rewards[proposer]
+		if income == nil || income.ProposerAttestationInclusionReward != 20 || income.ProposerSyncInclusionReward != 5 {
+			t.Fatalf("proposer %v: %v", proposer, income)
+		}
+		if fee := new(big.Int).SetBytes(income.TxFeeRewardWei); fee.Int64() != 1e9 || len(income.TxFeeRewards) != 1 {
+			t.Errorf("proposer %v: tx fee %v", proposer, fee)
+		}
+	}
+
+	member := res.Rewards[1]
+	if member.SyncCommitteeReward != 30 {
+		t.Errorf("sync committee reward %v, expected 30 for 3 proposed slots", member.SyncCommitteeReward)
+	}
+	if member.TotalClRewards() != 30+3+2+4 {
+		t.Errorf("total CL rewards %v", member.TotalClRewards())
+	}
+}
+
+func TestGetEpochRewardsFulu(t *testing.T) {
+	// the duties of epoch 2 depend on slot 3, which was missed, so on the block of slot 2
+	client := &mockBeacon{slotsPerEpoch: 4, missed: map[uint64]bool{3: true}, fulu: true}
+
+	res, err := newMockRewarder(client, 0).GetEpochRewards(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Slots) != 4 || res.Rewards[108] == nil {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	// the chain is verified against the last block before the epoch if fulu is not active yet
+	rewarder := newMockRewarder(client, 0)
+	rewarder.spec = &types.SpecApiResponse{Data: map[string]json.RawMessage{"FULU_FORK_EPOCH": json.RawMessage(`"3"`)}}
+	_, err = rewarder.GetEpochRewards(context.Background(), 2)
+	if !errors.Is(err, types.ErrChainReorg) {
+		t.Fatalf("expected ErrChainReorg for the pre fulu decision slot, got %v", err)
+	}
+}
+
+func TestGetEpochRewardsDependentRootChanged(t *testing.T) {
+	// the dependent root changes between the start and the verification of the computation
+	client := &mockBeacon{slotsPerEpoch: 4, reorgAfter: 1}
+
+	_, err := newMockRewarder(client, 0).GetEpochRewards(context.Background(), 2)
+	if !errors.Is(err, types.ErrChainReorg) {
+		t.Fatalf("expected ErrChainReorg, got %v", err)
+	}
+}
+
+func TestGetEpochRewardsReorgRetry(t *testing.T) {
+	// the dependent root changes permanently to a block the epoch does not descend from, so the restarted
+	// computation fails as well
+	client := &mockBeacon{slotsPerEpoch: 4, reorgAfter: 1}
+
+	_, err := newMockRewarder(client, 1).GetEpochRewards(context.Background(), 2)
+	if !errors.Is(err, types.ErrChainReorg) {
+		t.Fatalf("expected ErrChainReorg, got %v", err)
+	}
+	// the first attempt fails verifying the dependent root, the second one at the block the duties depend on
+	if client.dutiesCalls != 3 {
+		t.Errorf("expected 2 attempts with 3 proposer duties requests, got %v requests", client.dutiesCalls)
+	}
+}
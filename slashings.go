@@ -0,0 +1,292 @@
+package ethrewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// slashingParams are the fork dependent config values used to calculate slashing rewards and penalties.
+type slashingParams struct {
+	minPenaltyQuotient       uint64
+	whistleblowerQuotient    uint64
+	proposerWeight           uint64
+	weightDenominator        uint64
+	proportionalMultiplier   uint64
+	epochsPerSlashingsVector uint64
+	effectiveBalanceInc      uint64
+	electra                  bool
+}
+
+func (r *Rewarder) getSpec(ctx context.Context) (*types.SpecApiResponse, error) {
+	r.specMux.Lock()
+	defer r.specMux.Unlock()
+	if r.spec != nil {
+		return r.spec, nil
+	}
+	spec, err := r.client.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.spec = spec
+	return spec, nil
+}
+
+func (r *Rewarder) slashingParams(ctx context.Context, epoch uint64) (*slashingParams, error) {
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	value := func(name string) uint64 {
+		v, found := spec.Uint64(name)
+		if !found {
+			missing = append(missing, name)
+		}
+		return v
+	}
+	forkActive := func(fork string) bool {
+		forkEpoch, found := spec.Uint64(fork + "_FORK_EPOCH")
+		return found && forkEpoch <= epoch
+	}
+
+	p := &slashingParams{
+		epochsPerSlashingsVector: value("EPOCHS_PER_SLASHINGS_VECTOR"),
+		effectiveBalanceInc:      value("EFFECTIVE_BALANCE_INCREMENT"),
+		electra:                  forkActive("ELECTRA"),
+	}
+
+	switch {
+	case p.electra:
+		p.minPenaltyQuotient = value("MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA")
+		p.whistleblowerQuotient = value("WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA")
+	case forkActive("BELLATRIX"):
+		p.minPenaltyQuotient = value("MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX")
+	case forkActive("ALTAIR"):
+		p.minPenaltyQuotient = value("MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR")
+	default:
+		p.minPenaltyQuotient = value("MIN_SLASHING_PENALTY_QUOTIENT")
+	}
+	if !p.electra {
+		p.whistleblowerQuotient = value("WHISTLEBLOWER_REWARD_QUOTIENT")
+	}
+
+	switch {
+	case forkActive("BELLATRIX"):
+		p.proportionalMultiplier = value("PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX")
+	case forkActive("ALTAIR"):
+		p.proportionalMultiplier = value("PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR")
+	default:
+		p.proportionalMultiplier = value("PROPORTIONAL_SLASHING_MULTIPLIER")
+	}
+
+	if forkActive("ALTAIR") {
+		// not all nodes expose the altair incentive weights, they are constants of the spec
+		var found bool
+		if p.proposerWeight, found = spec.Uint64("PROPOSER_WEIGHT"); !found {
+			p.proposerWeight = 8
+		}
+		if p.weightDenominator, found = spec.Uint64("WEIGHT_DENOMINATOR"); !found {
+			p.weightDenominator = 64
+		}
+	} else {
+		p.proposerWeight = 1
+		p.weightDenominator = value("PROPOSER_REWARD_QUOTIENT")
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("beacon node config is missing %v", missing)
+	}
+	return p, nil
+}
+
+// blockSlashings calculates the immediate penalties of the validators slashed in the block and the
+// whistleblower rewards for including the slashings.
+func (r *Rewarder) blockSlashings(ctx context.Context, slot uint64, block *types.BeaconBlockApiResponse) ([]*Slashing, error) {
+	var slashed []string
+	seen := make(map[uint64]bool)
+	add := func(v uint64) {
+		if !seen[v] {
+			seen[v] = true
+			slashed = append(slashed, strconv.FormatUint(v, 10))
+		}
+	}
+	for _, v := range block.Data.ProposerSlashings {
+		add(v)
+	}
+	for _, as := range block.Data.AttesterSlashings {
+		for _, v := range as {
+			add(v)
+		}
+	}
+	if len(slashed) == 0 || slot == 0 {
+		return nil, nil
+	}
+
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slotsPerEpoch, found := spec.Uint64("SLOTS_PER_EPOCH")
+	if !found {
+		return nil, fmt.Errorf("beacon node config is missing SLOTS_PER_EPOCH")
+	}
+	params, err := r.slashingParams(ctx, slot/slotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// validators that already were slashed before the block are not slashed again
+	parent, err := r.client.BlockHeader(ctx, block.Data.ParentRoot)
+	if err != nil {
+		return nil, err
+	}
+	pre, err := r.client.Validators(ctx, parent.Data.StateRoot, slashed, nil)
+	if err != nil {
+		return nil, err
+	}
+	alreadySlashed := make(map[uint64]bool)
+	for _, v := range pre.Data {
+		alreadySlashed[v.Index] = v.Slashed
+	}
+
+	post, err := r.client.Validators(ctx, block.Data.StateRoot, slashed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var slashings []*Slashing
+	for _, v := range post.Data {
+		if alreadySlashed[v.Index] || !v.Slashed {
+			continue
+		}
+		whistleblowerReward := v.EffectiveBalance / params.whistleblowerQuotient
+		slashings = append(slashings, &Slashing{
+			Validator:           v.Index,
+			Penalty:             v.EffectiveBalance / params.minPenaltyQuotient,
+			WhistleblowerReward: whistleblowerReward,
+			ProposerReward:      whistleblowerReward * params.proposerWeight / params.weightDenominator,
+		})
+	}
+	return slashings, nil
+}
+
+// correlationPenalties calculates the proportional slashing penalties applied at the end of the epoch to
+// validators that reached the midpoint of their withdrawability delay.
+//
+// The slashings vector of the state is not exposed by the beacon API, the sum of recent slashings is
+// approximated by the effective balances of the validators slashed within the vector's period.
+func (r *Rewarder) correlationPenalties(ctx context.Context, epoch, slotsPerEpoch uint64) (map[uint64]uint64, error) {
+	params, err := r.slashingParams(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	stateID := strconv.FormatUint((epoch+1)*slotsPerEpoch-1, 10)
+
+	slashed, err := r.client.Validators(ctx, stateID, nil, []string{"active_slashed", "exited_slashed"})
+	if err != nil {
+		return nil, err
+	}
+
+	var penalized []*types.ValidatorContainer
+	slashingsSum := uint64(0)
+	for _, v := range slashed.Data {
+		if !v.Slashed {
+			continue
+		}
+		if v.WithdrawableEpoch == epoch+params.epochsPerSlashingsVector/2 {
+			penalized = append(penalized, v)
+		}
+		if v.WithdrawableEpoch > epoch && v.WithdrawableEpoch <= epoch+params.epochsPerSlashingsVector {
+			slashingsSum += v.EffectiveBalance
+		}
+	}
+	if len(penalized) == 0 {
+		return nil, nil
+	}
+
+	active, err := r.client.Validators(ctx, stateID, nil, []string{"active_ongoing", "active_exiting", "active_slashed"})
+	if err != nil {
+		return nil, err
+	}
+	totalBalance := uint64(0)
+	for _, v := range active.Data {
+		totalBalance += v.EffectiveBalance
+	}
+	if totalBalance < params.effectiveBalanceInc {
+		totalBalance = params.effectiveBalanceInc
+	}
+
+	adjusted := new(big.Int).Mul(new(big.Int).SetUint64(slashingsSum), new(big.Int).SetUint64(params.proportionalMultiplier))
+	total := new(big.Int).SetUint64(totalBalance)
+	if adjusted.Cmp(total) > 0 {
+		adjusted = total
+	}
+	increment := new(big.Int).SetUint64(params.effectiveBalanceInc)
+
+	penalties := make(map[uint64]uint64, len(penalized))
+	for _, v := range penalized {
+		increments := new(big.Int).SetUint64(v.EffectiveBalance / params.effectiveBalanceInc)
+		penalty := new(big.Int)
+		if params.electra {
+			perIncrement := new(big.Int).Div(adjusted, new(big.Int).Div(total, increment))
+			penalty.Mul(perIncrement, increments)
+		} else {
+			penalty.Mul(increments, adjusted)
+			penalty.Div(penalty, total)
+			penalty.Mul(penalty, increment)
+		}
+		penalties[v.Index] = penalty.Uint64()
+	}
+	return penalties, nil
+}
+
+// slashings adds the slashing penalties and whistleblower rewards of the epoch to the income.
+//
+// The block rewards reported by the beacon node include the complete whistleblower reward in the
+// proposer's slashing inclusion reward. The whistleblower's share exceeding the proposer reward is moved
+// to SlashingReward, so TotalClRewards does not count it twice. If the block rewards are not computed, the
+// complete whistleblower reward is added to SlashingReward.
+func (r *Rewarder) slashings(slots []*SlotReward, proposers map[uint64]*types.EpochProposerAssignmentsContainer, correlationPenalties map[uint64]uint64, rewards map[uint64]*types.ValidatorEpochIncome) {
+	income := func(v uint64) *types.ValidatorEpochIncome {
+		if rewards[v] == nil {
+			rewards[v] = &types.ValidatorEpochIncome{}
+		}
+		return rewards[v]
+	}
+
+	for _, slot := range slots {
+		for _, s := range slot.Slashings {
+			if r.inValidatorSet(s.Validator, rewards) {
+				income(s.Validator).SlashingPenalty += s.Penalty
+			}
+
+			if !r.includesProposer(proposers[slot.Slot]) {
+				continue
+			}
+			proposer := income(slot.Proposer)
+			if slot.BlockReward == nil {
+				// the proposer's share is not part of the income without the block rewards
+				proposer.SlashingReward += s.WhistleblowerReward
+				continue
+			}
+			whistleblowerShare := s.WhistleblowerReward - s.ProposerReward
+			proposer.SlashingReward += whistleblowerShare
+			if proposer.ProposerSlashingInclusionReward > whistleblowerShare {
+				proposer.ProposerSlashingInclusionReward -= whistleblowerShare
+			} else {
+				proposer.ProposerSlashingInclusionReward = 0
+			}
+		}
+	}
+
+	for v, penalty := range correlationPenalties {
+		if r.inValidatorSet(v, rewards) {
+			income(v).SlashingPenalty += penalty
+		}
+	}
+}
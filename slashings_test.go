@@ -0,0 +1,294 @@
+package ethrewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// slashingSpec are the mainnet slashing config values with the forks activated at epochs 10, 20 and 30.
+var slashingSpec = map[string]string{
+	"SLOTS_PER_EPOCH":                            "32",
+	"ALTAIR_FORK_EPOCH":                          "10",
+	"BELLATRIX_FORK_EPOCH":                       "20",
+	"ELECTRA_FORK_EPOCH":                         "30",
+	"EPOCHS_PER_SLASHINGS_VECTOR":                "8192",
+	"EFFECTIVE_BALANCE_INCREMENT":                "1000000000",
+	"MIN_SLASHING_PENALTY_QUOTIENT":              "128",
+	"MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR":       "64",
+	"MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX":    "32",
+	"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA":      "4096",
+	"WHISTLEBLOWER_REWARD_QUOTIENT":              "512",
+	"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA":      "4096",
+	"PROPORTIONAL_SLASHING_MULTIPLIER":           "1",
+	"PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR":    "2",
+	"PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX": "3",
+	"PROPOSER_REWARD_QUOTIENT":                   "8",
+}
+
+// slashingBeacon serves the slashing spec and the validators of the states by state id.
+type slashingBeacon struct {
+	*mockBeacon
+	states map[string][]*types.ValidatorContainer
+}
+
+func newSlashingBeacon() *slashingBeacon {
+	return &slashingBeacon{
+		mockBeacon: &mockBeacon{slotsPerEpoch: 32},
+		states:     make(map[string][]*types.ValidatorContainer),
+	}
+}
+
+func (m *slashingBeacon) Spec(ctx context.Context) (*types.SpecApiResponse, error) {
+	spec := &types.SpecApiResponse{Data: make(map[string]json.RawMessage, len(slashingSpec))}
+	for name, value := range slashingSpec {
+		spec.Data[name] = json.RawMessage(strconv.Quote(value))
+	}
+	return spec, nil
+}
+
+func (m *slashingBeacon) Validators(ctx context.Context, stateID string, ids []string, statuses []string) (*types.ValidatorsApiResponse, error) {
+	validators, found := m.states[stateID]
+	if !found {
+		return nil, fmt.Errorf("unknown state %v", stateID)
+	}
+
+	match := func(v *types.ValidatorContainer) bool {
+		if len(ids) > 0 {
+			found := false
+			for _, id := range ids {
+				found = found || id == strconv.FormatUint(v.Index, 10)
+			}
+			if !found {
+				return false
+			}
+		}
+		if len(statuses) > 0 {
+			found := false
+			for _, status := range statuses {
+				found = found || status == v.Status
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	r := &types.ValidatorsApiResponse{}
+	for _, v := range validators {
+		if match(v) {
+			r.Data = append(r.Data, v)
+		}
+	}
+	return r, nil
+}
+
+func TestCorrelationPenalties(t *testing.T) {
+	tests := []struct {
+		name    string
+		epoch   uint64
+		active  int // validators with 32 ETH besides the slashed ones
+		penalty uint64
+	}{
+		// 32 * 96 ETH * 1 / 3200 ETH rounded down to increments
+		{"phase0", 5, 100, 0},
+		// 32 * 96 ETH * 2 / 3200 ETH
+		{"altair", 15, 100, 1000000000},
+		// 32 * 96 ETH * 3 / 3200 ETH
+		{"bellatrix", 25, 100, 2000000000},
+		// 96 ETH * 3 / 3200 increments per increment, times 32
+		{"electra", 35, 100, 2880000000},
+		// the adjusted slashings balance is capped at the total balance of 64 ETH
+		{"total balance", 25, 2, 32000000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newSlashingBeacon()
+			slashed := func(index, withdrawableEpoch uint64) *types.ValidatorContainer {
+				return &types.ValidatorContainer{
+					Index:             index,
+					Status:            "exited_slashed",
+					EffectiveBalance:  32000000000,
+					Slashed:           true,
+					WithdrawableEpoch: withdrawableEpoch,
+				}
+			}
+			validators := []*types.ValidatorContainer{
+				// reaches the midpoint of the withdrawability delay
+				slashed(1, tt.epoch+4096),
+				// within the slashings vector
+				slashed(2, tt.epoch+100),
+				slashed(3, tt.epoch+8192),
+				// already withdrawable or slashed before the slashings vector's period
+				slashed(4, tt.epoch),
+				slashed(5, tt.epoch+8193),
+				// reported by the status filter but not slashed
+				{Index: 6, Status: "exited_slashed", EffectiveBalance: 32000000000, WithdrawableEpoch: tt.epoch + 4096},
+			}
+			for i := 0; i < tt.active; i++ {
+				validators = append(validators, &types.ValidatorContainer{
+					Index:            uint64(100 + i),
+					Status:           "active_ongoing",
+					EffectiveBalance: 32000000000,
+				})
+			}
+			client.states[strconv.FormatUint((tt.epoch+1)*32-1, 10)] = validators
+
+			penalties, err := NewRewarder(client, nil, Options{}).correlationPenalties(context.Background(), tt.epoch, 32)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(penalties) != 1 {
+				t.Fatalf("expected a penalty for validator 1, got %v", penalties)
+			}
+			if penalties[1] != tt.penalty {
+				t.Errorf("penalty %v, expected %v", penalties[1], tt.penalty)
+			}
+		})
+	}
+}
+
+func TestCorrelationPenaltiesNotPenalized(t *testing.T) {
+	client := newSlashingBeacon()
+	client.states[strconv.FormatUint(26*32-1, 10)] = []*types.ValidatorContainer{
+		{Index: 1, Status: "exited_slashed", EffectiveBalance: 32000000000, Slashed: true, WithdrawableEpoch: 25 + 4095},
+	}
+
+	penalties, err := NewRewarder(client, nil, Options{}).correlationPenalties(context.Background(), 25, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(penalties) != 0 {
+		t.Errorf("unexpected penalties %v", penalties)
+	}
+}
+
+func TestBlockSlashings(t *testing.T) {
+	tests := []struct {
+		name      string
+		epoch     uint64
+		slashings map[uint64]Slashing
+	}{
+		{"phase0", 5, map[uint64]Slashing{
+			// penalty 1/128, whistleblower 1/512 of the effective balance, the proposer gets 1/8 of it
+			7: {Validator: 7, Penalty: 250000000, WhistleblowerReward: 62500000, ProposerReward: 7812500},
+			8: {Validator: 8, Penalty: 242187500, WhistleblowerReward: 60546875, ProposerReward: 7568359},
+		}},
+		{"altair", 15, map[uint64]Slashing{
+			// penalty 1/64, the proposer gets 8/64 of the whistleblower reward
+			7: {Validator: 7, Penalty: 500000000, WhistleblowerReward: 62500000, ProposerReward: 7812500},
+			8: {Validator: 8, Penalty: 484375000, WhistleblowerReward: 60546875, ProposerReward: 7568359},
+		}},
+		{"bellatrix", 25, map[uint64]Slashing{
+			// penalty 1/32
+			7: {Validator: 7, Penalty: 1000000000, WhistleblowerReward: 62500000, ProposerReward: 7812500},
+			8: {Validator: 8, Penalty: 968750000, WhistleblowerReward: 60546875, ProposerReward: 7568359},
+		}},
+		{"electra", 35, map[uint64]Slashing{
+			// penalty and whistleblower reward 1/4096
+			7: {Validator: 7, Penalty: 7812500, WhistleblowerReward: 7812500, ProposerReward: 976562},
+			8: {Validator: 8, Penalty: 7568359, WhistleblowerReward: 7568359, ProposerReward: 946044},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newSlashingBeacon()
+			slot := tt.epoch*32 + 3
+
+			validator := func(index, effectiveBalance uint64, slashed bool) *types.ValidatorContainer {
+				return &types.ValidatorContainer{Index: index, EffectiveBalance: effectiveBalance, Slashed: slashed}
+			}
+			// validator 9 was slashed before the block
+			client.states[fmt.Sprintf("0x%064x", slot-1)] = []*types.ValidatorContainer{
+				validator(7, 32000000000, false),
+				validator(8, 31000000000, false),
+				validator(9, 32000000000, true),
+			}
+			client.states["post"] = []*types.ValidatorContainer{
+				validator(7, 32000000000, true),
+				validator(8, 31000000000, true),
+				validator(9, 32000000000, true),
+			}
+
+			block := &types.BeaconBlockApiResponse{Data: types.BeaconBlockContainer{
+				Slot:              slot,
+				ParentRoot:        blockRoot(slot - 1),
+				StateRoot:         "post",
+				ProposerSlashings: []uint64{7},
+				AttesterSlashings: [][]uint64{{7, 8}, {9}},
+			}}
+
+			slashings, err := NewRewarder(client, nil, Options{}).blockSlashings(context.Background(), slot, block)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(slashings) != len(tt.slashings) {
+				t.Fatalf("expected %v slashings, got %v", len(tt.slashings), len(slashings))
+			}
+			for _, s := range slashings {
+				if expected, found := tt.slashings[s.Validator]; !found || *s != expected {
+					t.Errorf("slashing %+v, expected %+v", *s, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestSlashingsWhistleblowerShare(t *testing.T) {
+	slots := []*SlotReward{{
+		Slot:        803,
+		Proposer:    50,
+		BlockReward: &types.BlockRewardsContainer{ProposerIndex: 50, ProposerSlashings: 62500000},
+		Slashings: []*Slashing{
+			{Validator: 7, Penalty: 1000000000, WhistleblowerReward: 62500000, ProposerReward: 7812500},
+		},
+	}}
+	proposers := map[uint64]*types.EpochProposerAssignmentsContainer{
+		803: {ValidatorIndex: 50, Slot: 803},
+	}
+	// the block rewards include the complete whistleblower reward
+	rewards := map[uint64]*types.ValidatorEpochIncome{
+		50: {ProposerSlashingInclusionReward: 62500000},
+	}
+
+	NewRewarder(newSlashingBeacon(), nil, Options{}).slashings(slots, proposers, map[uint64]uint64{7: 2000000000}, rewards)
+
+	if rewards[7].SlashingPenalty != 3000000000 {
+		t.Errorf("slashing penalty %v, expected the initial and the correlation penalty", rewards[7].SlashingPenalty)
+	}
+	proposer := rewards[50]
+	if proposer.ProposerSlashingInclusionReward != 7812500 || proposer.SlashingReward != 54687500 {
+		t.Errorf("proposer reward %v, whistleblower reward %v", proposer.ProposerSlashingInclusionReward, proposer.SlashingReward)
+	}
+	if proposer.TotalClRewards() != 62500000 {
+		t.Errorf("total CL rewards %v, expected the whistleblower reward", proposer.TotalClRewards())
+	}
+}
+
+func TestSlashingsWithoutBlockRewards(t *testing.T) {
+	slots := []*SlotReward{{
+		Slot:     803,
+		Proposer: 50,
+		Slashings: []*Slashing{
+			{Validator: 7, Penalty: 1000000000, WhistleblowerReward: 62500000, ProposerReward: 7812500},
+		},
+	}}
+	proposers := map[uint64]*types.EpochProposerAssignmentsContainer{
+		803: {ValidatorIndex: 50, Slot: 803},
+	}
+	rewards := map[uint64]*types.ValidatorEpochIncome{}
+
+	NewRewarder(newSlashingBeacon(), nil, Options{}).slashings(slots, proposers, nil, rewards)
+
+	proposer := rewards[50]
+	if proposer == nil || proposer.SlashingReward != 62500000 || proposer.ProposerSlashingInclusionReward != 0 {
+		t.Errorf("proposer income %v, expected the complete whistleblower reward", proposer)
+	}
+}
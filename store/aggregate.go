@@ -0,0 +1,85 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+const (
+	// MaxAggregateEpochs limits the epochs aggregated at once to about a year.
+	MaxAggregateEpochs = 225 * 365
+	// MaxUnfilteredAggregateEpochs limits the epochs aggregated over all validators to about a day, as every
+	// epoch has to be decoded completely.
+	MaxUnfilteredAggregateEpochs = 225
+)
+
+// ErrRangeTooLarge is returned by Aggregated if the epoch range exceeds MaxAggregateEpochs, or
+// MaxUnfilteredAggregateEpochs without validators.
+var ErrRangeTooLarge = errors.New("epoch range too large")
+
+// Aggregate is the income of validators summed up over a range of epochs.
+type Aggregate struct {
+	Income     *types.ValidatorEpochIncome // without the per block tx fee rewards
+	Epochs     int                         // number of complete epochs with income
+	Validators int                         // number of validators with income
+}
+
+// Aggregated sums up the income of the validators in the complete epochs from start to end (inclusive).
+// If validators is empty, the income of all validators is summed up.
+func Aggregated(s Store, start, end uint64, validators []uint64) (*Aggregate, error) {
+	if end >= start && end-start >= MaxAggregateEpochs {
+		return nil, fmt.Errorf("%w: at most %d epochs can be aggregated", ErrRangeTooLarge, MaxAggregateEpochs)
+	}
+	if len(validators) == 0 && end >= start && end-start >= MaxUnfilteredAggregateEpochs {
+		return nil, fmt.Errorf("%w: at most %d epochs can be aggregated without validators", ErrRangeTooLarge, MaxUnfilteredAggregateEpochs)
+	}
+
+	total := &types.ValidatorEpochIncome{}
+	epochs := make(map[uint64]bool)
+	seen := make(map[uint64]bool)
+	add := func(epoch, validator uint64, income *types.ValidatorEpochIncome) {
+		total.Add(income)
+		total.TxFeeRewards = nil
+		epochs[epoch] = true
+		seen[validator] = true
+	}
+
+	if len(validators) > 0 {
+		for _, v := range validators {
+			if seen[v] {
+				continue
+			}
+			rewards, err := s.ValidatorEpochs(v, start, end)
+			if err != nil {
+				return nil, err
+			}
+			for e, income := range rewards {
+				add(e, v, income)
+			}
+		}
+	} else {
+		for e := start; e <= end; e++ {
+			rewards, err := s.Epoch(e)
+			if errors.Is(err, ErrEpochNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for v, income := range rewards {
+				add(e, v, income)
+			}
+			if e == ^uint64(0) {
+				break
+			}
+		}
+	}
+
+	return &Aggregate{
+		Income:     total,
+		Epochs:     len(epochs),
+		Validators: len(seen),
+	}, nil
+}
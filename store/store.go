@@ -0,0 +1,171 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/gobitfly/eth-rewards/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrEpochNotFound = errors.New("epoch not found")
+
+// Store persists the computed income of the validators by epoch. An epoch is complete once the income of
+// all its validators was saved.
+type Store interface {
+	// SaveEpoch saves the income of the epoch and marks it as complete.
+	SaveEpoch(epoch uint64, rewards map[uint64]*types.ValidatorEpochIncome) error
+	// Epoch returns the income of a complete epoch, ErrEpochNotFound if the epoch is not complete.
+	Epoch(epoch uint64) (map[uint64]*types.ValidatorEpochIncome, error)
+	// ValidatorEpochs returns the income of the validator in the complete epochs from start to end
+	// (inclusive) by epoch.
+	ValidatorEpochs(validator, start, end uint64) (map[uint64]*types.ValidatorEpochIncome, error)
+	// Completed reports whether the epoch is complete.
+	Completed(epoch uint64) (bool, error)
+	// FirstIncomplete returns the first epoch at or after start that is not complete.
+	FirstIncomplete(start uint64) (uint64, error)
+	// LastCompleted returns the highest complete epoch, false if no epoch is complete.
+	LastCompleted() (uint64, bool, error)
+}
+
+// key prefixes of the LevelDB store
+var (
+	prefixIncome    = []byte("i/") // epoch, validator => ValidatorEpochIncome
+	prefixValidator = []byte("v/") // validator, epoch => empty, index of the income by validator
+	prefixCompleted = []byte("c/") // epoch => empty
+)
+
+// LevelDB is a Store persisted in a goleveldb database. The income is encoded as protobuf.
+type LevelDB struct {
+	db *leveldb.DB
+}
+
+var _ Store = (*LevelDB)(nil)
+
+// NewLevelDB opens the database at path, creating it if necessary.
+func NewLevelDB(path string) (*LevelDB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDB{db: db}, nil
+}
+
+func (s *LevelDB) Close() error {
+	return s.db.Close()
+}
+
+func key(prefix []byte, values ...uint64) []byte {
+	k := make([]byte, len(prefix)+8*len(values))
+	copy(k, prefix)
+	for i, v := range values {
+		binary.BigEndian.PutUint64(k[len(prefix)+8*i:], v)
+	}
+	return k
+}
+
+// SaveEpoch replaces the income of the epoch. The income and the completion mark are written in a single
+// batch, so an interrupted save leaves the epoch incomplete.
+func (s *LevelDB) SaveEpoch(epoch uint64, rewards map[uint64]*types.ValidatorEpochIncome) error {
+	batch := new(leveldb.Batch)
+
+	iter := s.db.NewIterator(util.BytesPrefix(key(prefixIncome, epoch)), nil)
+	for iter.Next() {
+		validator := binary.BigEndian.Uint64(iter.Key()[len(prefixIncome)+8:])
+		batch.Delete(key(prefixIncome, epoch, validator))
+		batch.Delete(key(prefixValidator, validator, epoch))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for validator, income := range rewards {
+		data, err := proto.Marshal(income)
+		if err != nil {
+			return err
+		}
+		batch.Put(key(prefixIncome, epoch, validator), data)
+		batch.Put(key(prefixValidator, validator, epoch), nil)
+	}
+	batch.Put(key(prefixCompleted, epoch), nil)
+
+	return s.db.Write(batch, nil)
+}
+
+func (s *LevelDB) Epoch(epoch uint64) (map[uint64]*types.ValidatorEpochIncome, error) {
+	completed, err := s.Completed(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, ErrEpochNotFound
+	}
+
+	rewards := make(map[uint64]*types.ValidatorEpochIncome)
+	iter := s.db.NewIterator(util.BytesPrefix(key(prefixIncome, epoch)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		income := &types.ValidatorEpochIncome{}
+		if err := proto.Unmarshal(iter.Value(), income); err != nil {
+			return nil, err
+		}
+		rewards[binary.BigEndian.Uint64(iter.Key()[len(prefixIncome)+8:])] = income
+	}
+	return rewards, iter.Error()
+}
+
+func (s *LevelDB) ValidatorEpochs(validator, start, end uint64) (map[uint64]*types.ValidatorEpochIncome, error) {
+	rewards := make(map[uint64]*types.ValidatorEpochIncome)
+	if end < start {
+		return rewards, nil
+	}
+
+	limit := key(prefixValidator, validator+1)
+	if end < ^uint64(0) {
+		limit = key(prefixValidator, validator, end+1)
+	}
+	iter := s.db.NewIterator(&util.Range{Start: key(prefixValidator, validator, start), Limit: limit}, nil)
+	defer iter.Release()
+	for iter.Next() {
+		epoch := binary.BigEndian.Uint64(iter.Key()[len(prefixValidator)+8:])
+		data, err := s.db.Get(key(prefixIncome, epoch, validator), nil)
+		if err != nil {
+			return nil, err
+		}
+		income := &types.ValidatorEpochIncome{}
+		if err := proto.Unmarshal(data, income); err != nil {
+			return nil, err
+		}
+		rewards[epoch] = income
+	}
+	return rewards, iter.Error()
+}
+
+func (s *LevelDB) Completed(epoch uint64) (bool, error) {
+	return s.db.Has(key(prefixCompleted, epoch), nil)
+}
+
+func (s *LevelDB) FirstIncomplete(start uint64) (uint64, error) {
+	iter := s.db.NewIterator(&util.Range{Start: key(prefixCompleted, start), Limit: util.BytesPrefix(prefixCompleted).Limit}, nil)
+	defer iter.Release()
+	epoch := start
+	for iter.Next() {
+		if binary.BigEndian.Uint64(iter.Key()[len(prefixCompleted):]) != epoch {
+			break
+		}
+		epoch++
+	}
+	return epoch, iter.Error()
+}
+
+func (s *LevelDB) LastCompleted() (uint64, bool, error) {
+	iter := s.db.NewIterator(util.BytesPrefix(prefixCompleted), nil)
+	defer iter.Release()
+	if !iter.Last() {
+		return 0, false, iter.Error()
+	}
+	return binary.BigEndian.Uint64(iter.Key()[len(prefixCompleted):]), true, nil
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+func newTestLevelDB(t *testing.T) *LevelDB {
+	s, err := NewLevelDB(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func income(reward uint64) *types.ValidatorEpochIncome {
+	return &types.ValidatorEpochIncome{AttestationHeadReward: reward}
+}
+
+func saveEpochs(t *testing.T, s *LevelDB, epochs ...uint64) {
+	for _, e := range epochs {
+		err := s.SaveEpoch(e, map[uint64]*types.ValidatorEpochIncome{
+			1: income(e),
+			2: income(e + 100),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompletedEpochs(t *testing.T) {
+	s := newTestLevelDB(t)
+
+	if _, found, err := s.LastCompleted(); err != nil || found {
+		t.Fatalf("empty store: found %v, err %v", found, err)
+	}
+	if first, err := s.FirstIncomplete(5); err != nil || first != 5 {
+		t.Fatalf("empty store: first incomplete %v, err %v", first, err)
+	}
+
+	saveEpochs(t, s, 0, 1, 2, 4, 5, 1<<32)
+
+	last, found, err := s.LastCompleted()
+	if err != nil || !found || last != 1<<32 {
+		t.Errorf("last completed %v, found %v, err %v", last, found, err)
+	}
+
+	for _, tt := range []struct {
+		start, first uint64
+	}{
+		{0, 3},
+		{2, 3},
+		{3, 3},
+		{4, 6},
+		{6, 6},
+		{1 << 32, 1<<32 + 1},
+	} {
+		first, err := s.FirstIncomplete(tt.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != tt.first {
+			t.Errorf("first incomplete from %v: %v, expected %v", tt.start, first, tt.first)
+		}
+	}
+
+	if _, err := s.Epoch(3); !errors.Is(err, ErrEpochNotFound) {
+		t.Errorf("expected ErrEpochNotFound for a missing epoch, got %v", err)
+	}
+}
+
+func TestValidatorEpochs(t *testing.T) {
+	s := newTestLevelDB(t)
+	saveEpochs(t, s, 1, 2, 3, 5, ^uint64(0))
+
+	for _, tt := range []struct {
+		start, end uint64
+		epochs     []uint64
+	}{
+		{0, 10, []uint64{1, 2, 3, 5}},
+		{2, 3, []uint64{2, 3}},
+		{4, 4, nil},
+		{3, 2, nil},
+		{5, ^uint64(0), []uint64{5, ^uint64(0)}},
+	} {
+		rewards, err := s.ValidatorEpochs(2, tt.start, tt.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rewards) != len(tt.epochs) {
+			t.Errorf("epochs %v - %v: got %v epochs, expected %v", tt.start, tt.end, len(rewards), tt.epochs)
+			continue
+		}
+		for _, e := range tt.epochs {
+			if rewards[e] == nil || rewards[e].AttestationHeadReward != e+100 {
+				t.Errorf("epochs %v - %v: epoch %v income %v", tt.start, tt.end, e, rewards[e])
+			}
+		}
+	}
+
+	// the index of a validator does not include the epochs of the following validator
+	rewards, err := s.ValidatorEpochs(1, 0, ^uint64(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewards) != 5 || rewards[3].AttestationHeadReward != 3 {
+		t.Errorf("validator 1: %v", rewards)
+	}
+}
+
+func TestSaveEpochOverwrite(t *testing.T) {
+	s := newTestLevelDB(t)
+	saveEpochs(t, s, 7)
+
+	err := s.SaveEpoch(7, map[uint64]*types.ValidatorEpochIncome{
+		2: income(42),
+		3: income(43),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewards, err := s.Epoch(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewards) != 2 || rewards[2].AttestationHeadReward != 42 || rewards[3].AttestationHeadReward != 43 {
+		t.Errorf("epoch 7: %v", rewards)
+	}
+
+	// the validator index of the replaced income is removed as well
+	validator, err := s.ValidatorEpochs(1, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validator) != 0 {
+		t.Errorf("validator 1 still has income %v", validator)
+	}
+	validator, err = s.ValidatorEpochs(2, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validator) != 1 || validator[7].AttestationHeadReward != 42 {
+		t.Errorf("validator 2: %v", validator)
+	}
+}
+
+func TestAggregatedLimits(t *testing.T) {
+	s := newTestLevelDB(t)
+	saveEpochs(t, s, 1, 2)
+
+	aggregate, err := Aggregated(s, 0, MaxUnfilteredAggregateEpochs-1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aggregate.Epochs != 2 || aggregate.Validators != 2 || aggregate.Income.AttestationHeadReward != 1+2+101+102 {
+		t.Errorf("unexpected aggregate %+v", aggregate)
+	}
+
+	if _, err := Aggregated(s, 0, MaxUnfilteredAggregateEpochs, nil); !errors.Is(err, ErrRangeTooLarge) {
+		t.Errorf("expected ErrRangeTooLarge without validators, got %v", err)
+	}
+	if _, err := Aggregated(s, 0, MaxUnfilteredAggregateEpochs, []uint64{1}); err != nil {
+		t.Errorf("unexpected error with validators: %v", err)
+	}
+	if _, err := Aggregated(s, 0, MaxAggregateEpochs, []uint64{1}); !errors.Is(err, ErrRangeTooLarge) {
+		t.Errorf("expected ErrRangeTooLarge, got %v", err)
+	}
+}
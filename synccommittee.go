@@ -0,0 +1,94 @@
+package ethrewards
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+// syncCommittee returns the validator indices of the epoch's sync committee by committee position, nil if
+// the epoch is pre altair.
+func (r *Rewarder) syncCommittee(ctx context.Context, epoch, slotsPerEpoch uint64) ([]uint64, error) {
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	altairEpoch, found := spec.Uint64("ALTAIR_FORK_EPOCH")
+	if !found || altairEpoch > epoch {
+		return nil, nil
+	}
+
+	sc, err := r.client.SyncCommittee(ctx, strconv.FormatUint(epoch*slotsPerEpoch, 10), epoch)
+	if err != nil {
+		return nil, err
+	}
+	return sc.Data.Validators, nil
+}
+
+// syncCommitteeParticipation adds the missed sync committee slots and the rewards forgone by them to the
+// income of the committee members.
+//
+// A member missed a slot if its participation bit in the slot's sync aggregate is not set or if the block
+// of the slot was missed. The participant reward per committee position is derived from the sync committee
+// rewards of the epoch. Not participating in an included aggregate forgoes the participant reward and is
+// penalized by the same amount, while a missed block only forgoes the reward.
+func (r *Rewarder) syncCommitteeParticipation(slots []*SlotReward, committee []uint64, rewards map[uint64]*types.ValidatorEpochIncome) {
+	if len(committee) == 0 {
+		return
+	}
+
+	positions := make(map[uint64][]int)
+	for i, v := range committee {
+		positions[v] = append(positions[v], i)
+	}
+
+	// a member's reward is the participant reward times the participating minus the missed positions
+	participantReward := uint64(0)
+	for _, slot := range slots {
+		if slot.SyncCommitteeParticipation == nil || participantReward > 0 {
+			continue
+		}
+		for _, sr := range slot.SyncCommitteeRewards {
+			balance := int64(0)
+			for _, p := range positions[sr.ValidatorIndex] {
+				if p < len(slot.SyncCommitteeParticipation) && slot.SyncCommitteeParticipation[p] {
+					balance++
+				} else {
+					balance--
+				}
+			}
+			if balance != 0 && sr.Reward != 0 && sr.Reward%balance == 0 {
+				participantReward = uint64(sr.Reward / balance)
+				break
+			}
+		}
+	}
+
+	for _, slot := range slots {
+		if !slot.Missed && slot.SyncCommitteeParticipation == nil {
+			continue
+		}
+		for v, ps := range positions {
+			missed := uint64(0)
+			for _, p := range ps {
+				if slot.Missed || p >= len(slot.SyncCommitteeParticipation) || !slot.SyncCommitteeParticipation[p] {
+					missed++
+				}
+			}
+			if missed == 0 || !r.inValidatorSet(v, rewards) {
+				continue
+			}
+
+			if rewards[v] == nil {
+				rewards[v] = &types.ValidatorEpochIncome{}
+			}
+			rewards[v].SyncCommitteeMissedSlots += 1
+			if slot.Missed {
+				rewards[v].SyncCommitteeMissedReward += missed * participantReward
+			} else {
+				rewards[v].SyncCommitteeMissedReward += 2 * missed * participantReward
+			}
+		}
+	}
+}
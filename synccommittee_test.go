@@ -0,0 +1,86 @@
+package ethrewards
+
+import (
+	"testing"
+
+	"github.com/gobitfly/eth-rewards/types"
+)
+
+func TestSyncCommitteeParticipation(t *testing.T) {
+	// validator 10 holds two committee positions, validator 12 did not participate in slot 1
+	committee := []uint64{10, 11, 12, 10}
+
+	tests := []struct {
+		name       string
+		validators []string
+		rewards    []*types.SyncCommitteeRewardsContainer // of slot 1, deriving the participant reward of 10
+		missed     map[uint64][2]uint64                   // missed slots and missed reward by validator
+	}{
+		{
+			name: "all validators",
+			rewards: []*types.SyncCommitteeRewardsContainer{
+				{ValidatorIndex: 10, Reward: 20},
+				{ValidatorIndex: 11, Reward: 10},
+				{ValidatorIndex: 12, Reward: -10},
+			},
+			missed: map[uint64][2]uint64{
+				// the absent position forgoes the reward and is penalized, the missed block only forgoes it
+				12: {2, 2*10 + 10},
+				10: {1, 2 * 10},
+				11: {1, 10},
+			},
+		},
+		{
+			name:       "reward derived from a penalty",
+			validators: []string{"12"},
+			rewards: []*types.SyncCommitteeRewardsContainer{
+				{ValidatorIndex: 12, Reward: -10},
+			},
+			missed: map[uint64][2]uint64{
+				12: {2, 2*10 + 10},
+			},
+		},
+		{
+			name:       "validator set",
+			validators: []string{"10"},
+			rewards: []*types.SyncCommitteeRewardsContainer{
+				{ValidatorIndex: 10, Reward: 20},
+			},
+			missed: map[uint64][2]uint64{
+				10: {1, 2 * 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := []*SlotReward{
+				{Slot: 0, SyncCommitteeParticipation: []bool{true, true, true, true}},
+				{Slot: 1, SyncCommitteeParticipation: []bool{true, true, false, true}, SyncCommitteeRewards: tt.rewards},
+				{Slot: 2, Missed: true},
+				// pre altair or not fetched
+				{Slot: 3},
+			}
+			rewards := make(map[uint64]*types.ValidatorEpochIncome)
+
+			NewRewarder(&mockBeacon{}, nil, Options{Validators: tt.validators}).syncCommitteeParticipation(slots, committee, rewards)
+
+			for v, income := range rewards {
+				expected, found := tt.missed[v]
+				if !found {
+					if income.SyncCommitteeMissedSlots != 0 || income.SyncCommitteeMissedReward != 0 {
+						t.Errorf("validator %v: unexpected missed slots %v", v, income.SyncCommitteeMissedSlots)
+					}
+					continue
+				}
+				if income.SyncCommitteeMissedSlots != expected[0] || income.SyncCommitteeMissedReward != expected[1] {
+					t.Errorf("validator %v: %v missed slots and %v missed reward, expected %v", v,
+						income.SyncCommitteeMissedSlots, income.SyncCommitteeMissedReward, expected)
+				}
+			}
+			if len(rewards) != len(tt.missed) {
+				t.Errorf("income of %v validators, expected %v", len(rewards), len(tt.missed))
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +13,9 @@ import (
 var ErrBlockNotFound = errors.New("block not found")
 var ErrSlotPreMerge = errors.New("slot is pre merge")
 var ErrSlotPreSyncCommittees = errors.New("slot is pre sync committees")
+var ErrChainReorg = errors.New("chain reorganized during computation")
+var ErrEpochNotFinalized = errors.New("epoch is not finalized")
+var ErrExecutionOptimistic = errors.New("data is execution optimistic")
 
 type TxReceipt struct {
 	BlockHash         *common.Hash    `json:"blockHash"`
@@ -43,10 +47,67 @@ func (income *ValidatorEpochIncome) TotalClRewards() int64 {
 		income.AttestationTargetPenalty +
 		income.FinalityDelayPenalty +
 		income.SyncCommitteePenalty +
-		income.SlashingPenalty
+		income.SlashingPenalty +
+		income.InactivityPenalty
 	return int64(rewards) - int64(penalties)
 }
 
+// MissedAttestationRewards is the difference between the ideal and the actual attestation rewards.
+func (income *ValidatorEpochIncome) MissedAttestationRewards() uint64 {
+	return income.AttestationSourceMissedReward +
+		income.AttestationTargetMissedReward +
+		income.AttestationHeadMissedReward
+}
+
+// AttestationEfficiency is the percentage of the ideal source, target and head rewards the validator earned.
+func (income *ValidatorEpochIncome) AttestationEfficiency() float64 {
+	actual := int64(income.AttestationSourceReward+income.AttestationTargetReward+income.AttestationHeadReward) -
+		int64(income.AttestationSourcePenalty+income.AttestationTargetPenalty)
+	ideal := actual + int64(income.MissedAttestationRewards())
+	if ideal <= 0 || actual <= 0 {
+		return 0
+	}
+	return float64(actual) / float64(ideal) * 100
+}
+
+// Add adds the income of other, e.g. to aggregate the income over several epochs or validators.
+func (income *ValidatorEpochIncome) Add(other *ValidatorEpochIncome) {
+	income.AttestationSourceReward += other.AttestationSourceReward
+	income.AttestationSourcePenalty += other.AttestationSourcePenalty
+	income.AttestationTargetReward += other.AttestationTargetReward
+	income.AttestationTargetPenalty += other.AttestationTargetPenalty
+	income.AttestationHeadReward += other.AttestationHeadReward
+	income.FinalityDelayPenalty += other.FinalityDelayPenalty
+	income.ProposerSlashingInclusionReward += other.ProposerSlashingInclusionReward
+	income.ProposerAttestationInclusionReward += other.ProposerAttestationInclusionReward
+	income.ProposerSyncInclusionReward += other.ProposerSyncInclusionReward
+	income.SyncCommitteeReward += other.SyncCommitteeReward
+	income.SyncCommitteePenalty += other.SyncCommitteePenalty
+	income.SlashingReward += other.SlashingReward
+	income.SlashingPenalty += other.SlashingPenalty
+	income.TxFeeRewardWei = addWei(income.TxFeeRewardWei, other.TxFeeRewardWei)
+	income.ProposalsMissed += other.ProposalsMissed
+	income.TxFeeRewards = append(income.TxFeeRewards, other.TxFeeRewards...)
+	income.WithdrawalsAmount += other.WithdrawalsAmount
+	income.WithdrawalsCount += other.WithdrawalsCount
+	income.InactivityPenalty += other.InactivityPenalty
+	income.AttestationSourceMissedReward += other.AttestationSourceMissedReward
+	income.AttestationTargetMissedReward += other.AttestationTargetMissedReward
+	income.AttestationHeadMissedReward += other.AttestationHeadMissedReward
+	income.ProposalsMissedClReward += other.ProposalsMissedClReward
+	income.ProposalsMissedElRewardWei = addWei(income.ProposalsMissedElRewardWei, other.ProposalsMissedElRewardWei)
+	income.SyncCommitteeMissedSlots += other.SyncCommitteeMissedSlots
+	income.SyncCommitteeMissedReward += other.SyncCommitteeMissedReward
+}
+
+func addWei(a, b []byte) []byte {
+	if len(b) == 0 {
+		return a
+	}
+	sum := new(big.Int).SetBytes(a)
+	return sum.Add(sum, new(big.Int).SetBytes(b)).Bytes()
+}
+
 type AttestationRewardsApiResponse struct {
 	Data struct {
 		IdealRewards []*IdealAttestationRewardContainer  `json:"ideal_rewards"`
@@ -65,6 +126,7 @@ type TotalAttestationRewardsContainer struct {
 	Source         int64  `json:"source"`
 	Target         int64  `json:"target"`
 	InclusionDelay int64  `json:"inclusion_delay"`
+	Inactivity     int64  `json:"inactivity"`
 	ValidatorIndex uint64 `json:"validator_index"`
 }
 
@@ -82,6 +144,7 @@ func (a *AttestationRewardsApiResponse) UnmarshalJSON(data []byte) error {
 				Source         string `json:"source"`
 				Target         string `json:"target"`
 				InclusionDelay string `json:"inclusion_delay"`
+				Inactivity     string `json:"inactivity"`
 				ValidatorIndex string `json:"validator_index"`
 			} `json:"total_rewards"`
 		} `json:"data"`
@@ -93,6 +156,8 @@ func (a *AttestationRewardsApiResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	a.ExecutionOptimistic = v.ExecutionOptimistic
+
 	a.Data.IdealRewards = make([]*IdealAttestationRewardContainer, len(v.Data.IdealRewards))
 	a.Data.TotalRewards = make([]*TotalAttestationRewardsContainer, len(v.Data.TotalRewards))
 
@@ -153,6 +218,13 @@ func (a *AttestationRewardsApiResponse) UnmarshalJSON(data []byte) error {
 			}
 		}
 
+		if r.Inactivity != "" {
+			p.Inactivity, err = strconv.ParseInt(r.Inactivity, 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+
 		a.Data.TotalRewards[i] = p
 	}
 
@@ -182,6 +254,8 @@ func (b *BalanceApiResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	b.ExecutionOptimistic = v.ExecutionOptimistic
+
 	var err error
 
 	b.Data = make([]struct {
@@ -204,15 +278,17 @@ func (b *BalanceApiResponse) UnmarshalJSON(data []byte) error {
 }
 
 type BlockRewardsApiResponse struct {
-	Data struct {
-		Attestations      uint64 `json:"attestations"`
-		AttesterSlashings uint64 `json:"attester_slashings"`
-		ProposerIndex     uint64 `json:"proposer_index"`
-		ProposerSlashings uint64 `json:"proposer_slashings"`
-		SyncAggregate     uint64 `json:"sync_aggregate"`
-		Total             uint64 `json:"total"`
-	} `json:"data"`
-	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Data                BlockRewardsContainer `json:"data"`
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+}
+
+type BlockRewardsContainer struct {
+	Attestations      uint64 `json:"attestations"`
+	AttesterSlashings uint64 `json:"attester_slashings"`
+	ProposerIndex     uint64 `json:"proposer_index"`
+	ProposerSlashings uint64 `json:"proposer_slashings"`
+	SyncAggregate     uint64 `json:"sync_aggregate"`
+	Total             uint64 `json:"total"`
 }
 
 func (b *BlockRewardsApiResponse) UnmarshalJSON(data []byte) error {
@@ -234,6 +310,8 @@ func (b *BlockRewardsApiResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	b.ExecutionOptimistic = v.ExecutionOptimistic
+
 	var err error
 	b.Data.Attestations, err = strconv.ParseUint(v.Data.Attestations, 10, 64)
 	if err != nil {
@@ -287,6 +365,8 @@ func (s *SyncCommitteeRewardsApiResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	s.ExecutionOptimistic = v.ExecutionOptimistic
+
 	s.Data = make([]*SyncCommitteeRewardsContainer, len(v.Data))
 
 	var err error
@@ -348,11 +428,16 @@ func (e *EpochProposerAssignmentsApiResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	e.DependentRoot = v.DependentRoot
+	e.ExecutionOptimistic = v.ExecutionOptimistic
+
 	e.Data = make([]*EpochProposerAssignmentsContainer, len(v.Data))
 
 	var err error
 	for i, r := range v.Data {
-		p := &EpochProposerAssignmentsContainer{}
+		p := &EpochProposerAssignmentsContainer{
+			Pubkey: r.Pubkey,
+		}
 
 		p.ValidatorIndex, err = strconv.ParseInt(r.ValidatorIndex, 10, 64)
 		if err != nil {
@@ -368,3 +453,555 @@ func (e *EpochProposerAssignmentsApiResponse) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+type BlockHeaderApiResponse struct {
+	Data struct {
+		Root          string `json:"root"`
+		Canonical     bool   `json:"canonical"`
+		Slot          uint64 `json:"slot"`
+		ProposerIndex uint64 `json:"proposer_index"`
+		ParentRoot    string `json:"parent_root"`
+		StateRoot     string `json:"state_root"`
+	} `json:"data"`
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+}
+
+func (b *BlockHeaderApiResponse) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Data struct {
+			Root      string `json:"root"`
+			Canonical bool   `json:"canonical"`
+			Header    struct {
+				Message struct {
+					Slot          string `json:"slot"`
+					ProposerIndex string `json:"proposer_index"`
+					ParentRoot    string `json:"parent_root"`
+					StateRoot     string `json:"state_root"`
+				} `json:"message"`
+			} `json:"header"`
+		} `json:"data"`
+		ExecutionOptimistic bool `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	b.Data.Slot, err = strconv.ParseUint(v.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return err
+	}
+	b.Data.ProposerIndex, err = strconv.ParseUint(v.Data.Header.Message.ProposerIndex, 10, 64)
+	if err != nil {
+		return err
+	}
+	b.Data.Root = v.Data.Root
+	b.Data.Canonical = v.Data.Canonical
+	b.Data.ParentRoot = v.Data.Header.Message.ParentRoot
+	b.Data.StateRoot = v.Data.Header.Message.StateRoot
+	b.ExecutionOptimistic = v.ExecutionOptimistic
+
+	return nil
+}
+
+type BeaconBlockApiResponse struct {
+	Data                BeaconBlockContainer `json:"data"`
+	ExecutionOptimistic bool                 `json:"execution_optimistic"`
+}
+
+type BeaconBlockContainer struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	// ExecutionPayload is nil if the block is pre merge
+	ExecutionPayload *ExecutionPayload `json:"execution_payload"`
+	Deposits         []*Deposit        `json:"deposits"`
+	// ProposerSlashings are the indices of the proposers slashed in the block
+	ProposerSlashings []uint64 `json:"proposer_slashings"`
+	// AttesterSlashings are the indices of the validators attesting to both conflicting attestations,
+	// for each attester slashing of the block
+	AttesterSlashings [][]uint64 `json:"attester_slashings"`
+	// SyncCommitteeBits is the participation bitvector of the sync aggregate, nil pre altair
+	SyncCommitteeBits []byte `json:"sync_committee_bits"`
+}
+
+// SyncCommitteeParticipated reports whether the sync committee member at the position participated in the
+// block's sync aggregate.
+func (b *BeaconBlockContainer) SyncCommitteeParticipated(position int) bool {
+	if position/8 >= len(b.SyncCommitteeBits) {
+		return false
+	}
+	return b.SyncCommitteeBits[position/8]&(1<<(position%8)) != 0
+}
+
+type Deposit struct {
+	Pubkey string `json:"pubkey"`
+	Amount uint64 `json:"amount"` // gwei
+}
+
+type ExecutionPayload struct {
+	BlockNumber       uint64        `json:"block_number"`
+	BlockHash         string        `json:"block_hash"`
+	FeeRecipient      string        `json:"fee_recipient"`
+	TransactionsCount int           `json:"transactions_count"`
+	Withdrawals       []*Withdrawal `json:"withdrawals"`
+}
+
+type Withdrawal struct {
+	Index          uint64 `json:"index"`
+	ValidatorIndex uint64 `json:"validator_index"`
+	Address        string `json:"address"`
+	Amount         uint64 `json:"amount"` // gwei
+}
+
+func (b *BeaconBlockApiResponse) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Data struct {
+			Message struct {
+				Slot          string `json:"slot"`
+				ProposerIndex string `json:"proposer_index"`
+				ParentRoot    string `json:"parent_root"`
+				StateRoot     string `json:"state_root"`
+				Body          struct {
+					SyncAggregate *struct {
+						SyncCommitteeBits string `json:"sync_committee_bits"`
+					} `json:"sync_aggregate"`
+					ProposerSlashings []struct {
+						SignedHeader1 struct {
+							Message struct {
+								ProposerIndex string `json:"proposer_index"`
+							} `json:"message"`
+						} `json:"signed_header_1"`
+					} `json:"proposer_slashings"`
+					AttesterSlashings []struct {
+						Attestation1 struct {
+							AttestingIndices []string `json:"attesting_indices"`
+						} `json:"attestation_1"`
+						Attestation2 struct {
+							AttestingIndices []string `json:"attesting_indices"`
+						} `json:"attestation_2"`
+					} `json:"attester_slashings"`
+					Deposits []struct {
+						Data struct {
+							Pubkey string `json:"pubkey"`
+							Amount string `json:"amount"`
+						} `json:"data"`
+					} `json:"deposits"`
+					ExecutionPayload struct {
+						BlockNumber  string   `json:"block_number"`
+						BlockHash    string   `json:"block_hash"`
+						FeeRecipient string   `json:"fee_recipient"`
+						Transactions []string `json:"transactions"`
+						Withdrawals  []struct {
+							Index          string `json:"index"`
+							ValidatorIndex string `json:"validator_index"`
+							Address        string `json:"address"`
+							Amount         string `json:"amount"`
+						} `json:"withdrawals"`
+					} `json:"execution_payload"`
+				} `json:"body"`
+			} `json:"message"`
+		} `json:"data"`
+		ExecutionOptimistic bool `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	msg := v.Data.Message
+	b.Data.Slot, err = strconv.ParseUint(msg.Slot, 10, 64)
+	if err != nil {
+		return err
+	}
+	b.Data.ProposerIndex, err = strconv.ParseUint(msg.ProposerIndex, 10, 64)
+	if err != nil {
+		return err
+	}
+	b.Data.ParentRoot = msg.ParentRoot
+	b.Data.StateRoot = msg.StateRoot
+	b.ExecutionOptimistic = v.ExecutionOptimistic
+
+	b.Data.Deposits = make([]*Deposit, len(msg.Body.Deposits))
+	for i, d := range msg.Body.Deposits {
+		p := &Deposit{
+			Pubkey: d.Data.Pubkey,
+		}
+		p.Amount, err = strconv.ParseUint(d.Data.Amount, 10, 64)
+		if err != nil {
+			return err
+		}
+		b.Data.Deposits[i] = p
+	}
+
+	if msg.Body.SyncAggregate != nil {
+		b.Data.SyncCommitteeBits, err = hexutil.Decode(msg.Body.SyncAggregate.SyncCommitteeBits)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.Data.ProposerSlashings = make([]uint64, len(msg.Body.ProposerSlashings))
+	for i, ps := range msg.Body.ProposerSlashings {
+		b.Data.ProposerSlashings[i], err = strconv.ParseUint(ps.SignedHeader1.Message.ProposerIndex, 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.Data.AttesterSlashings = make([][]uint64, len(msg.Body.AttesterSlashings))
+	for i, as := range msg.Body.AttesterSlashings {
+		attesting := make(map[string]bool, len(as.Attestation1.AttestingIndices))
+		for _, index := range as.Attestation1.AttestingIndices {
+			attesting[index] = true
+		}
+		for _, index := range as.Attestation2.AttestingIndices {
+			if !attesting[index] {
+				continue
+			}
+			v, err := strconv.ParseUint(index, 10, 64)
+			if err != nil {
+				return err
+			}
+			b.Data.AttesterSlashings[i] = append(b.Data.AttesterSlashings[i], v)
+		}
+	}
+
+	payload := msg.Body.ExecutionPayload
+	// pre merge blocks either have no payload or, since bellatrix, an empty one
+	if payload.BlockNumber == "" || common.HexToHash(payload.BlockHash) == (common.Hash{}) {
+		return nil
+	}
+
+	b.Data.ExecutionPayload = &ExecutionPayload{
+		BlockHash:         payload.BlockHash,
+		FeeRecipient:      payload.FeeRecipient,
+		TransactionsCount: len(payload.Transactions),
+		Withdrawals:       make([]*Withdrawal, len(payload.Withdrawals)),
+	}
+	b.Data.ExecutionPayload.BlockNumber, err = strconv.ParseUint(payload.BlockNumber, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	for i, w := range payload.Withdrawals {
+		p := &Withdrawal{
+			Address: w.Address,
+		}
+
+		p.Index, err = strconv.ParseUint(w.Index, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.ValidatorIndex, err = strconv.ParseUint(w.ValidatorIndex, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.Amount, err = strconv.ParseUint(w.Amount, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		b.Data.ExecutionPayload.Withdrawals[i] = p
+	}
+
+	return nil
+}
+
+type ValidatorsApiResponse struct {
+	Data                []*ValidatorContainer `json:"data"`
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+}
+
+type ValidatorContainer struct {
+	Index             uint64 `json:"index"`
+	Balance           uint64 `json:"balance"`
+	Status            string `json:"status"`
+	Pubkey            string `json:"pubkey"`
+	EffectiveBalance  uint64 `json:"effective_balance"`
+	Slashed           bool   `json:"slashed"`
+	ActivationEpoch   uint64 `json:"activation_epoch"`
+	ExitEpoch         uint64 `json:"exit_epoch"`
+	WithdrawableEpoch uint64 `json:"withdrawable_epoch"`
+}
+
+func (r *ValidatorsApiResponse) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Data []struct {
+			Index     string `json:"index"`
+			Balance   string `json:"balance"`
+			Status    string `json:"status"`
+			Validator struct {
+				Pubkey            string `json:"pubkey"`
+				EffectiveBalance  string `json:"effective_balance"`
+				Slashed           bool   `json:"slashed"`
+				ActivationEpoch   string `json:"activation_epoch"`
+				ExitEpoch         string `json:"exit_epoch"`
+				WithdrawableEpoch string `json:"withdrawable_epoch"`
+			} `json:"validator"`
+		} `json:"data"`
+		ExecutionOptimistic bool `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	r.Data = make([]*ValidatorContainer, len(v.Data))
+	r.ExecutionOptimistic = v.ExecutionOptimistic
+
+	var err error
+	for i, d := range v.Data {
+		p := &ValidatorContainer{
+			Status:  d.Status,
+			Pubkey:  d.Validator.Pubkey,
+			Slashed: d.Validator.Slashed,
+		}
+
+		p.Index, err = strconv.ParseUint(d.Index, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.Balance, err = strconv.ParseUint(d.Balance, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.EffectiveBalance, err = strconv.ParseUint(d.Validator.EffectiveBalance, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.ActivationEpoch, err = strconv.ParseUint(d.Validator.ActivationEpoch, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.ExitEpoch, err = strconv.ParseUint(d.Validator.ExitEpoch, 10, 64)
+		if err != nil {
+			return err
+		}
+		p.WithdrawableEpoch, err = strconv.ParseUint(d.Validator.WithdrawableEpoch, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		r.Data[i] = p
+	}
+
+	return nil
+}
+
+type SpecApiResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// Uint64 returns the numeric config value with the given name.
+func (s *SpecApiResponse) Uint64(name string) (uint64, bool) {
+	raw, found := s.Data[name]
+	if !found {
+		return 0, false
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+type SyncCommitteeApiResponse struct {
+	Data struct {
+		// Validators are the indices of the committee members by position
+		Validators []uint64 `json:"validators"`
+	} `json:"data"`
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+}
+
+func (s *SyncCommitteeApiResponse) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Data struct {
+			Validators []string `json:"validators"`
+		} `json:"data"`
+		ExecutionOptimistic bool `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	s.Data.Validators = make([]uint64, len(v.Data.Validators))
+	s.ExecutionOptimistic = v.ExecutionOptimistic
+
+	var err error
+	for i, index := range v.Data.Validators {
+		s.Data.Validators[i], err = strconv.ParseUint(index, 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type Checkpoint struct {
+	Epoch uint64 `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+func (c *Checkpoint) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Epoch string `json:"epoch"`
+		Root  string `json:"root"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	c.Epoch, err = strconv.ParseUint(v.Epoch, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.Root = v.Root
+	return nil
+}
+
+type FinalityCheckpointsApiResponse struct {
+	Data struct {
+		PreviousJustified Checkpoint `json:"previous_justified"`
+		CurrentJustified  Checkpoint `json:"current_justified"`
+		Finalized         Checkpoint `json:"finalized"`
+	} `json:"data"`
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+}
+
+type GenesisApiResponse struct {
+	Data struct {
+		GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	} `json:"data"`
+}
+
+// Event is a server-sent event of the beacon node's event stream.
+type Event struct {
+	Topic string
+	Data  []byte
+}
+
+type HeadEvent struct {
+	Slot                uint64 `json:"slot"`
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	EpochTransition     bool   `json:"epoch_transition"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+func (h *HeadEvent) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Slot                string `json:"slot"`
+		Block               string `json:"block"`
+		State               string `json:"state"`
+		EpochTransition     bool   `json:"epoch_transition"`
+		ExecutionOptimistic bool   `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	h.Slot, err = strconv.ParseUint(v.Slot, 10, 64)
+	if err != nil {
+		return err
+	}
+	h.Block = v.Block
+	h.State = v.State
+	h.EpochTransition = v.EpochTransition
+	h.ExecutionOptimistic = v.ExecutionOptimistic
+	return nil
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	Epoch               uint64 `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+func (f *FinalizedCheckpointEvent) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Block               string `json:"block"`
+		State               string `json:"state"`
+		Epoch               string `json:"epoch"`
+		ExecutionOptimistic bool   `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	f.Epoch, err = strconv.ParseUint(v.Epoch, 10, 64)
+	if err != nil {
+		return err
+	}
+	f.Block = v.Block
+	f.State = v.State
+	f.ExecutionOptimistic = v.ExecutionOptimistic
+	return nil
+}
+
+type ChainReorgEvent struct {
+	Slot                uint64 `json:"slot"`
+	Depth               uint64 `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	Epoch               uint64 `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+func (c *ChainReorgEvent) UnmarshalJSON(data []byte) error {
+	type internal struct {
+		Slot                string `json:"slot"`
+		Depth               string `json:"depth"`
+		OldHeadBlock        string `json:"old_head_block"`
+		NewHeadBlock        string `json:"new_head_block"`
+		Epoch               string `json:"epoch"`
+		ExecutionOptimistic bool   `json:"execution_optimistic"`
+	}
+
+	var v internal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	c.Slot, err = strconv.ParseUint(v.Slot, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.Depth, err = strconv.ParseUint(v.Depth, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.Epoch, err = strconv.ParseUint(v.Epoch, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.OldHeadBlock = v.OldHeadBlock
+	c.NewHeadBlock = v.NewHeadBlock
+	c.ExecutionOptimistic = v.ExecutionOptimistic
+	return nil
+}
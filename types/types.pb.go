@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.6.1
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
 // source: types/types.proto
 
 package types
@@ -25,21 +25,32 @@ type ValidatorEpochIncome struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AttestationSourceReward            uint64 `protobuf:"varint,2,opt,name=attestation_source_reward,json=attestationSourceReward,proto3" json:"attestation_source_reward,omitempty"`
-	AttestationSourcePenalty           uint64 `protobuf:"varint,3,opt,name=attestation_source_penalty,json=attestationSourcePenalty,proto3" json:"attestation_source_penalty,omitempty"`
-	AttestationTargetReward            uint64 `protobuf:"varint,4,opt,name=attestation_target_reward,json=attestationTargetReward,proto3" json:"attestation_target_reward,omitempty"`
-	AttestationTargetPenalty           uint64 `protobuf:"varint,5,opt,name=attestation_target_penalty,json=attestationTargetPenalty,proto3" json:"attestation_target_penalty,omitempty"`
-	AttestationHeadReward              uint64 `protobuf:"varint,6,opt,name=attestation_head_reward,json=attestationHeadReward,proto3" json:"attestation_head_reward,omitempty"`
-	FinalityDelayPenalty               uint64 `protobuf:"varint,7,opt,name=finality_delay_penalty,json=finalityDelayPenalty,proto3" json:"finality_delay_penalty,omitempty"`
-	ProposerSlashingInclusionReward    uint64 `protobuf:"varint,8,opt,name=proposer_slashing_inclusion_reward,json=proposerSlashingInclusionReward,proto3" json:"proposer_slashing_inclusion_reward,omitempty"`
-	ProposerAttestationInclusionReward uint64 `protobuf:"varint,9,opt,name=proposer_attestation_inclusion_reward,json=proposerAttestationInclusionReward,proto3" json:"proposer_attestation_inclusion_reward,omitempty"`
-	ProposerSyncInclusionReward        uint64 `protobuf:"varint,10,opt,name=proposer_sync_inclusion_reward,json=proposerSyncInclusionReward,proto3" json:"proposer_sync_inclusion_reward,omitempty"`
-	SyncCommitteeReward                uint64 `protobuf:"varint,11,opt,name=sync_committee_reward,json=syncCommitteeReward,proto3" json:"sync_committee_reward,omitempty"`
-	SyncCommitteePenalty               uint64 `protobuf:"varint,12,opt,name=sync_committee_penalty,json=syncCommitteePenalty,proto3" json:"sync_committee_penalty,omitempty"`
-	SlashingReward                     uint64 `protobuf:"varint,13,opt,name=slashing_reward,json=slashingReward,proto3" json:"slashing_reward,omitempty"`
-	SlashingPenalty                    uint64 `protobuf:"varint,14,opt,name=slashing_penalty,json=slashingPenalty,proto3" json:"slashing_penalty,omitempty"`
-	TxFeeRewardWei                     []byte `protobuf:"bytes,15,opt,name=tx_fee_reward_wei,json=txFeeRewardWei,proto3" json:"tx_fee_reward_wei,omitempty"`
-	ProposalsMissed                    uint64 `protobuf:"varint,16,opt,name=proposals_missed,json=proposalsMissed,proto3" json:"proposals_missed,omitempty"`
+	AttestationSourceReward            uint64              `protobuf:"varint,2,opt,name=attestation_source_reward,json=attestationSourceReward,proto3" json:"attestation_source_reward,omitempty"`
+	AttestationSourcePenalty           uint64              `protobuf:"varint,3,opt,name=attestation_source_penalty,json=attestationSourcePenalty,proto3" json:"attestation_source_penalty,omitempty"`
+	AttestationTargetReward            uint64              `protobuf:"varint,4,opt,name=attestation_target_reward,json=attestationTargetReward,proto3" json:"attestation_target_reward,omitempty"`
+	AttestationTargetPenalty           uint64              `protobuf:"varint,5,opt,name=attestation_target_penalty,json=attestationTargetPenalty,proto3" json:"attestation_target_penalty,omitempty"`
+	AttestationHeadReward              uint64              `protobuf:"varint,6,opt,name=attestation_head_reward,json=attestationHeadReward,proto3" json:"attestation_head_reward,omitempty"`
+	FinalityDelayPenalty               uint64              `protobuf:"varint,7,opt,name=finality_delay_penalty,json=finalityDelayPenalty,proto3" json:"finality_delay_penalty,omitempty"`
+	ProposerSlashingInclusionReward    uint64              `protobuf:"varint,8,opt,name=proposer_slashing_inclusion_reward,json=proposerSlashingInclusionReward,proto3" json:"proposer_slashing_inclusion_reward,omitempty"`
+	ProposerAttestationInclusionReward uint64              `protobuf:"varint,9,opt,name=proposer_attestation_inclusion_reward,json=proposerAttestationInclusionReward,proto3" json:"proposer_attestation_inclusion_reward,omitempty"`
+	ProposerSyncInclusionReward        uint64              `protobuf:"varint,10,opt,name=proposer_sync_inclusion_reward,json=proposerSyncInclusionReward,proto3" json:"proposer_sync_inclusion_reward,omitempty"`
+	SyncCommitteeReward                uint64              `protobuf:"varint,11,opt,name=sync_committee_reward,json=syncCommitteeReward,proto3" json:"sync_committee_reward,omitempty"`
+	SyncCommitteePenalty               uint64              `protobuf:"varint,12,opt,name=sync_committee_penalty,json=syncCommitteePenalty,proto3" json:"sync_committee_penalty,omitempty"`
+	SlashingReward                     uint64              `protobuf:"varint,13,opt,name=slashing_reward,json=slashingReward,proto3" json:"slashing_reward,omitempty"`
+	SlashingPenalty                    uint64              `protobuf:"varint,14,opt,name=slashing_penalty,json=slashingPenalty,proto3" json:"slashing_penalty,omitempty"`
+	TxFeeRewardWei                     []byte              `protobuf:"bytes,15,opt,name=tx_fee_reward_wei,json=txFeeRewardWei,proto3" json:"tx_fee_reward_wei,omitempty"`
+	ProposalsMissed                    uint64              `protobuf:"varint,16,opt,name=proposals_missed,json=proposalsMissed,proto3" json:"proposals_missed,omitempty"`
+	TxFeeRewards                       []*BlockTxFeeReward `protobuf:"bytes,17,rep,name=tx_fee_rewards,json=txFeeRewards,proto3" json:"tx_fee_rewards,omitempty"`
+	WithdrawalsAmount                  uint64              `protobuf:"varint,18,opt,name=withdrawals_amount,json=withdrawalsAmount,proto3" json:"withdrawals_amount,omitempty"`
+	WithdrawalsCount                   uint64              `protobuf:"varint,19,opt,name=withdrawals_count,json=withdrawalsCount,proto3" json:"withdrawals_count,omitempty"`
+	InactivityPenalty                  uint64              `protobuf:"varint,20,opt,name=inactivity_penalty,json=inactivityPenalty,proto3" json:"inactivity_penalty,omitempty"`
+	AttestationSourceMissedReward      uint64              `protobuf:"varint,21,opt,name=attestation_source_missed_reward,json=attestationSourceMissedReward,proto3" json:"attestation_source_missed_reward,omitempty"`
+	AttestationTargetMissedReward      uint64              `protobuf:"varint,22,opt,name=attestation_target_missed_reward,json=attestationTargetMissedReward,proto3" json:"attestation_target_missed_reward,omitempty"`
+	AttestationHeadMissedReward        uint64              `protobuf:"varint,23,opt,name=attestation_head_missed_reward,json=attestationHeadMissedReward,proto3" json:"attestation_head_missed_reward,omitempty"`
+	ProposalsMissedClReward            uint64              `protobuf:"varint,24,opt,name=proposals_missed_cl_reward,json=proposalsMissedClReward,proto3" json:"proposals_missed_cl_reward,omitempty"`
+	ProposalsMissedElRewardWei         []byte              `protobuf:"bytes,25,opt,name=proposals_missed_el_reward_wei,json=proposalsMissedElRewardWei,proto3" json:"proposals_missed_el_reward_wei,omitempty"`
+	SyncCommitteeMissedSlots           uint64              `protobuf:"varint,26,opt,name=sync_committee_missed_slots,json=syncCommitteeMissedSlots,proto3" json:"sync_committee_missed_slots,omitempty"`
+	SyncCommitteeMissedReward          uint64              `protobuf:"varint,27,opt,name=sync_committee_missed_reward,json=syncCommitteeMissedReward,proto3" json:"sync_committee_missed_reward,omitempty"`
 }
 
 func (x *ValidatorEpochIncome) Reset() {
@@ -179,11 +190,561 @@ func (x *ValidatorEpochIncome) GetProposalsMissed() uint64 {
 	return 0
 }
 
+func (x *ValidatorEpochIncome) GetTxFeeRewards() []*BlockTxFeeReward {
+	if x != nil {
+		return x.TxFeeRewards
+	}
+	return nil
+}
+
+func (x *ValidatorEpochIncome) GetWithdrawalsAmount() uint64 {
+	if x != nil {
+		return x.WithdrawalsAmount
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetWithdrawalsCount() uint64 {
+	if x != nil {
+		return x.WithdrawalsCount
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetInactivityPenalty() uint64 {
+	if x != nil {
+		return x.InactivityPenalty
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetAttestationSourceMissedReward() uint64 {
+	if x != nil {
+		return x.AttestationSourceMissedReward
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetAttestationTargetMissedReward() uint64 {
+	if x != nil {
+		return x.AttestationTargetMissedReward
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetAttestationHeadMissedReward() uint64 {
+	if x != nil {
+		return x.AttestationHeadMissedReward
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetProposalsMissedClReward() uint64 {
+	if x != nil {
+		return x.ProposalsMissedClReward
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetProposalsMissedElRewardWei() []byte {
+	if x != nil {
+		return x.ProposalsMissedElRewardWei
+	}
+	return nil
+}
+
+func (x *ValidatorEpochIncome) GetSyncCommitteeMissedSlots() uint64 {
+	if x != nil {
+		return x.SyncCommitteeMissedSlots
+	}
+	return 0
+}
+
+func (x *ValidatorEpochIncome) GetSyncCommitteeMissedReward() uint64 {
+	if x != nil {
+		return x.SyncCommitteeMissedReward
+	}
+	return 0
+}
+
+type BlockTxFeeReward struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot            uint64 `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	ExecBlockNumber uint64 `protobuf:"varint,2,opt,name=exec_block_number,json=execBlockNumber,proto3" json:"exec_block_number,omitempty"`
+	FeeRewardWei    []byte `protobuf:"bytes,3,opt,name=fee_reward_wei,json=feeRewardWei,proto3" json:"fee_reward_wei,omitempty"`
+}
+
+func (x *BlockTxFeeReward) Reset() {
+	*x = BlockTxFeeReward{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockTxFeeReward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockTxFeeReward) ProtoMessage() {}
+
+func (x *BlockTxFeeReward) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockTxFeeReward.ProtoReflect.Descriptor instead.
+func (*BlockTxFeeReward) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockTxFeeReward) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *BlockTxFeeReward) GetExecBlockNumber() uint64 {
+	if x != nil {
+		return x.ExecBlockNumber
+	}
+	return 0
+}
+
+func (x *BlockTxFeeReward) GetFeeRewardWei() []byte {
+	if x != nil {
+		return x.FeeRewardWei
+	}
+	return nil
+}
+
+type GetEpochRewardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// validators are the validator indices to return, required. The income of all validators is served by
+	// StreamValidatorRewards.
+	Validators []uint64 `protobuf:"varint,2,rep,packed,name=validators,proto3" json:"validators,omitempty"`
+}
+
+func (x *GetEpochRewardsRequest) Reset() {
+	*x = GetEpochRewardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEpochRewardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEpochRewardsRequest) ProtoMessage() {}
+
+func (x *GetEpochRewardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEpochRewardsRequest.ProtoReflect.Descriptor instead.
+func (*GetEpochRewardsRequest) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEpochRewardsRequest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GetEpochRewardsRequest) GetValidators() []uint64 {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type GetEpochRewardsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch   uint64                           `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Rewards map[uint64]*ValidatorEpochIncome `protobuf:"bytes,2,rep,name=rewards,proto3" json:"rewards,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// attestation_efficiency is the percentage of the ideal attestation rewards earned by validator
+	AttestationEfficiency map[uint64]float64 `protobuf:"bytes,3,rep,name=attestation_efficiency,json=attestationEfficiency,proto3" json:"attestation_efficiency,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (x *GetEpochRewardsResponse) Reset() {
+	*x = GetEpochRewardsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEpochRewardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEpochRewardsResponse) ProtoMessage() {}
+
+func (x *GetEpochRewardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEpochRewardsResponse.ProtoReflect.Descriptor instead.
+func (*GetEpochRewardsResponse) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEpochRewardsResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GetEpochRewardsResponse) GetRewards() map[uint64]*ValidatorEpochIncome {
+	if x != nil {
+		return x.Rewards
+	}
+	return nil
+}
+
+func (x *GetEpochRewardsResponse) GetAttestationEfficiency() map[uint64]float64 {
+	if x != nil {
+		return x.AttestationEfficiency
+	}
+	return nil
+}
+
+type StreamValidatorRewardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validators []uint64 `protobuf:"varint,1,rep,packed,name=validators,proto3" json:"validators,omitempty"`
+	FromEpoch  uint64   `protobuf:"varint,2,opt,name=from_epoch,json=fromEpoch,proto3" json:"from_epoch,omitempty"`
+	// to_epoch defaults to the last complete epoch if unset
+	ToEpoch *uint64 `protobuf:"varint,3,opt,name=to_epoch,json=toEpoch,proto3,oneof" json:"to_epoch,omitempty"`
+}
+
+func (x *StreamValidatorRewardsRequest) Reset() {
+	*x = StreamValidatorRewardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamValidatorRewardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamValidatorRewardsRequest) ProtoMessage() {}
+
+func (x *StreamValidatorRewardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamValidatorRewardsRequest.ProtoReflect.Descriptor instead.
+func (*StreamValidatorRewardsRequest) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamValidatorRewardsRequest) GetValidators() []uint64 {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *StreamValidatorRewardsRequest) GetFromEpoch() uint64 {
+	if x != nil {
+		return x.FromEpoch
+	}
+	return 0
+}
+
+func (x *StreamValidatorRewardsRequest) GetToEpoch() uint64 {
+	if x != nil && x.ToEpoch != nil {
+		return *x.ToEpoch
+	}
+	return 0
+}
+
+type ValidatorRewards struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch     uint64                `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Validator uint64                `protobuf:"varint,2,opt,name=validator,proto3" json:"validator,omitempty"`
+	Income    *ValidatorEpochIncome `protobuf:"bytes,3,opt,name=income,proto3" json:"income,omitempty"`
+	// attestation_efficiency is the percentage of the ideal attestation rewards earned
+	AttestationEfficiency float64 `protobuf:"fixed64,4,opt,name=attestation_efficiency,json=attestationEfficiency,proto3" json:"attestation_efficiency,omitempty"`
+}
+
+func (x *ValidatorRewards) Reset() {
+	*x = ValidatorRewards{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorRewards) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorRewards) ProtoMessage() {}
+
+func (x *ValidatorRewards) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorRewards.ProtoReflect.Descriptor instead.
+func (*ValidatorRewards) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValidatorRewards) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ValidatorRewards) GetValidator() uint64 {
+	if x != nil {
+		return x.Validator
+	}
+	return 0
+}
+
+func (x *ValidatorRewards) GetIncome() *ValidatorEpochIncome {
+	if x != nil {
+		return x.Income
+	}
+	return nil
+}
+
+func (x *ValidatorRewards) GetAttestationEfficiency() float64 {
+	if x != nil {
+		return x.AttestationEfficiency
+	}
+	return 0
+}
+
+type GetAggregateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromEpoch uint64 `protobuf:"varint,1,opt,name=from_epoch,json=fromEpoch,proto3" json:"from_epoch,omitempty"`
+	// to_epoch defaults to the last complete epoch if unset
+	ToEpoch *uint64 `protobuf:"varint,2,opt,name=to_epoch,json=toEpoch,proto3,oneof" json:"to_epoch,omitempty"`
+	// validators restricts the aggregate to the given validator indices, empty for all validators
+	Validators []uint64 `protobuf:"varint,3,rep,packed,name=validators,proto3" json:"validators,omitempty"`
+}
+
+func (x *GetAggregateRequest) Reset() {
+	*x = GetAggregateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateRequest) ProtoMessage() {}
+
+func (x *GetAggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregateRequest) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAggregateRequest) GetFromEpoch() uint64 {
+	if x != nil {
+		return x.FromEpoch
+	}
+	return 0
+}
+
+func (x *GetAggregateRequest) GetToEpoch() uint64 {
+	if x != nil && x.ToEpoch != nil {
+		return *x.ToEpoch
+	}
+	return 0
+}
+
+func (x *GetAggregateRequest) GetValidators() []uint64 {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+type GetAggregateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromEpoch  uint64                `protobuf:"varint,1,opt,name=from_epoch,json=fromEpoch,proto3" json:"from_epoch,omitempty"`
+	ToEpoch    uint64                `protobuf:"varint,2,opt,name=to_epoch,json=toEpoch,proto3" json:"to_epoch,omitempty"`
+	Epochs     uint64                `protobuf:"varint,3,opt,name=epochs,proto3" json:"epochs,omitempty"`
+	Validators uint64                `protobuf:"varint,4,opt,name=validators,proto3" json:"validators,omitempty"`
+	Income     *ValidatorEpochIncome `protobuf:"bytes,5,opt,name=income,proto3" json:"income,omitempty"`
+	// attestation_efficiency is the percentage of the ideal attestation rewards earned
+	AttestationEfficiency float64 `protobuf:"fixed64,6,opt,name=attestation_efficiency,json=attestationEfficiency,proto3" json:"attestation_efficiency,omitempty"`
+}
+
+func (x *GetAggregateResponse) Reset() {
+	*x = GetAggregateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateResponse) ProtoMessage() {}
+
+func (x *GetAggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateResponse.ProtoReflect.Descriptor instead.
+func (*GetAggregateResponse) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAggregateResponse) GetFromEpoch() uint64 {
+	if x != nil {
+		return x.FromEpoch
+	}
+	return 0
+}
+
+func (x *GetAggregateResponse) GetToEpoch() uint64 {
+	if x != nil {
+		return x.ToEpoch
+	}
+	return 0
+}
+
+func (x *GetAggregateResponse) GetEpochs() uint64 {
+	if x != nil {
+		return x.Epochs
+	}
+	return 0
+}
+
+func (x *GetAggregateResponse) GetValidators() uint64 {
+	if x != nil {
+		return x.Validators
+	}
+	return 0
+}
+
+func (x *GetAggregateResponse) GetIncome() *ValidatorEpochIncome {
+	if x != nil {
+		return x.Income
+	}
+	return nil
+}
+
+func (x *GetAggregateResponse) GetAttestationEfficiency() float64 {
+	if x != nil {
+		return x.AttestationEfficiency
+	}
+	return 0
+}
+
 var File_types_types_proto protoreflect.FileDescriptor
 
 var file_types_types_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xf1, 0x06, 0x0a, 0x14, 0x56,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x93, 0x0c, 0x0a, 0x14, 0x56,
 	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x63,
 	0x6f, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64,
@@ -238,9 +799,148 @@ var file_types_types_proto_rawDesc = []byte{
 	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x74, 0x78, 0x46, 0x65, 0x65, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
 	0x57, 0x65, 0x69, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73,
 	0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x70,
-	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42, 0x09,
-	0x5a, 0x07, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12, 0x3d,
+	0x0a, 0x0e, 0x74, 0x78, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x46, 0x65, 0x65, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x52,
+	0x0c, 0x74, 0x78, 0x46, 0x65, 0x65, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x2d, 0x0a,
+	0x12, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x5f, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x77, 0x69, 0x74, 0x68, 0x64,
+	0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x61, 0x6c, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x79, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x12, 0x47, 0x0a, 0x20, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d,
+	0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x1d, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x12, 0x47, 0x0a, 0x20, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1d, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x69,
+	0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x43, 0x0a, 0x1e, 0x61, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x6d,
+	0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x1b, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x65, 0x61, 0x64, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12,
+	0x3b, 0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x5f, 0x6d, 0x69, 0x73,
+	0x73, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x17, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x4d, 0x69,
+	0x73, 0x73, 0x65, 0x64, 0x43, 0x6c, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x42, 0x0a, 0x1e,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64,
+	0x5f, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x4d,
+	0x69, 0x73, 0x73, 0x65, 0x64, 0x45, 0x6c, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69,
+	0x12, 0x3d, 0x0a, 0x1b, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74,
+	0x65, 0x65, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x18, 0x73, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x12,
+	0x3f, 0x0a, 0x1c, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x65, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x19, 0x73, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x22, 0x78, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x46, 0x65, 0x65, 0x52, 0x65,
+	0x77, 0x61, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x78, 0x65, 0x63,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x66, 0x65,
+	0x65, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x22, 0x4e, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x8b, 0x03, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x45, 0x0a, 0x07,
+	0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65,
+	0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65,
+	0x77, 0x61, 0x72, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x73, 0x12, 0x70, 0x0a, 0x16, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x15,
+	0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66, 0x69, 0x63,
+	0x69, 0x65, 0x6e, 0x63, 0x79, 0x1a, 0x57, 0x0a, 0x0c, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x63,
+	0x6f, 0x6d, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x48,
+	0x0a, 0x1a, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66,
+	0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8b, 0x01, 0x0a, 0x1d, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x08, 0x74, 0x6f, 0x5f,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x07, 0x74,
+	0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x74, 0x6f,
+	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x33, 0x0a, 0x06, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x06, 0x69, 0x6e,
+	0x63, 0x6f, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x16, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x1e, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x07, 0x74, 0x6f, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x88,
+	0x01, 0x01, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x74, 0x6f, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22,
+	0xf4, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x72,
+	0x6f, 0x6d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x69, 0x6e,
+	0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x06, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x12,
+	0x35, 0x0a, 0x16, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65,
+	0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x15, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x66, 0x66, 0x69,
+	0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x32, 0xff, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x50, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65,
+	0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x30, 0x01, 0x12,
+	0x47, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12,
+	0x1a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -255,16 +955,37 @@ func file_types_types_proto_rawDescGZIP() []byte {
 	return file_types_types_proto_rawDescData
 }
 
-var file_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_types_types_proto_goTypes = []interface{}{
-	(*ValidatorEpochIncome)(nil), // 0: types.ValidatorEpochIncome
+	(*ValidatorEpochIncome)(nil),          // 0: types.ValidatorEpochIncome
+	(*BlockTxFeeReward)(nil),              // 1: types.BlockTxFeeReward
+	(*GetEpochRewardsRequest)(nil),        // 2: types.GetEpochRewardsRequest
+	(*GetEpochRewardsResponse)(nil),       // 3: types.GetEpochRewardsResponse
+	(*StreamValidatorRewardsRequest)(nil), // 4: types.StreamValidatorRewardsRequest
+	(*ValidatorRewards)(nil),              // 5: types.ValidatorRewards
+	(*GetAggregateRequest)(nil),           // 6: types.GetAggregateRequest
+	(*GetAggregateResponse)(nil),          // 7: types.GetAggregateResponse
+	nil,                                   // 8: types.GetEpochRewardsResponse.RewardsEntry
+	nil,                                   // 9: types.GetEpochRewardsResponse.AttestationEfficiencyEntry
 }
 var file_types_types_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: types.ValidatorEpochIncome.tx_fee_rewards:type_name -> types.BlockTxFeeReward
+	8, // 1: types.GetEpochRewardsResponse.rewards:type_name -> types.GetEpochRewardsResponse.RewardsEntry
+	9, // 2: types.GetEpochRewardsResponse.attestation_efficiency:type_name -> types.GetEpochRewardsResponse.AttestationEfficiencyEntry
+	0, // 3: types.ValidatorRewards.income:type_name -> types.ValidatorEpochIncome
+	0, // 4: types.GetAggregateResponse.income:type_name -> types.ValidatorEpochIncome
+	0, // 5: types.GetEpochRewardsResponse.RewardsEntry.value:type_name -> types.ValidatorEpochIncome
+	2, // 6: types.Rewards.GetEpochRewards:input_type -> types.GetEpochRewardsRequest
+	4, // 7: types.Rewards.StreamValidatorRewards:input_type -> types.StreamValidatorRewardsRequest
+	6, // 8: types.Rewards.GetAggregate:input_type -> types.GetAggregateRequest
+	3, // 9: types.Rewards.GetEpochRewards:output_type -> types.GetEpochRewardsResponse
+	5, // 10: types.Rewards.StreamValidatorRewards:output_type -> types.ValidatorRewards
+	7, // 11: types.Rewards.GetAggregate:output_type -> types.GetAggregateResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_types_types_proto_init() }
@@ -285,16 +1006,102 @@ func file_types_types_proto_init() {
 				return nil
 			}
 		}
+		file_types_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockTxFeeReward); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEpochRewardsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEpochRewardsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamValidatorRewardsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorRewards); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAggregateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAggregateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_types_types_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_types_types_proto_msgTypes[6].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_types_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   10,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_types_types_proto_goTypes,
 		DependencyIndexes: file_types_types_proto_depIdxs,
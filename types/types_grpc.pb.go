@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: types/types.proto
+
+package types
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RewardsClient is the client API for Rewards service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RewardsClient interface {
+	GetEpochRewards(ctx context.Context, in *GetEpochRewardsRequest, opts ...grpc.CallOption) (*GetEpochRewardsResponse, error)
+	// StreamValidatorRewards streams the income of the validators ordered by epoch and validator.
+	StreamValidatorRewards(ctx context.Context, in *StreamValidatorRewardsRequest, opts ...grpc.CallOption) (Rewards_StreamValidatorRewardsClient, error)
+	GetAggregate(ctx context.Context, in *GetAggregateRequest, opts ...grpc.CallOption) (*GetAggregateResponse, error)
+}
+
+type rewardsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRewardsClient(cc grpc.ClientConnInterface) RewardsClient {
+	return &rewardsClient{cc}
+}
+
+func (c *rewardsClient) GetEpochRewards(ctx context.Context, in *GetEpochRewardsRequest, opts ...grpc.CallOption) (*GetEpochRewardsResponse, error) {
+	out := new(GetEpochRewardsResponse)
+	err := c.cc.Invoke(ctx, "/types.Rewards/GetEpochRewards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardsClient) StreamValidatorRewards(ctx context.Context, in *StreamValidatorRewardsRequest, opts ...grpc.CallOption) (Rewards_StreamValidatorRewardsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Rewards_ServiceDesc.Streams[0], "/types.Rewards/StreamValidatorRewards", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rewardsStreamValidatorRewardsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Rewards_StreamValidatorRewardsClient interface {
+	Recv() (*ValidatorRewards, error)
+	grpc.ClientStream
+}
+
+type rewardsStreamValidatorRewardsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rewardsStreamValidatorRewardsClient) Recv() (*ValidatorRewards, error) {
+	m := new(ValidatorRewards)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rewardsClient) GetAggregate(ctx context.Context, in *GetAggregateRequest, opts ...grpc.CallOption) (*GetAggregateResponse, error) {
+	out := new(GetAggregateResponse)
+	err := c.cc.Invoke(ctx, "/types.Rewards/GetAggregate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RewardsServer is the server API for Rewards service.
+// All implementations must embed UnimplementedRewardsServer
+// for forward compatibility
+type RewardsServer interface {
+	GetEpochRewards(context.Context, *GetEpochRewardsRequest) (*GetEpochRewardsResponse, error)
+	// StreamValidatorRewards streams the income of the validators ordered by epoch and validator.
+	StreamValidatorRewards(*StreamValidatorRewardsRequest, Rewards_StreamValidatorRewardsServer) error
+	GetAggregate(context.Context, *GetAggregateRequest) (*GetAggregateResponse, error)
+	mustEmbedUnimplementedRewardsServer()
+}
+
+// UnimplementedRewardsServer must be embedded to have forward compatible implementations.
+type UnimplementedRewardsServer struct {
+}
+
+func (UnimplementedRewardsServer) GetEpochRewards(context.Context, *GetEpochRewardsRequest) (*GetEpochRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEpochRewards not implemented")
+}
+func (UnimplementedRewardsServer) StreamValidatorRewards(*StreamValidatorRewardsRequest, Rewards_StreamValidatorRewardsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamValidatorRewards not implemented")
+}
+func (UnimplementedRewardsServer) GetAggregate(context.Context, *GetAggregateRequest) (*GetAggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregate not implemented")
+}
+func (UnimplementedRewardsServer) mustEmbedUnimplementedRewardsServer() {}
+
+// UnsafeRewardsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RewardsServer will
+// result in compilation errors.
+type UnsafeRewardsServer interface {
+	mustEmbedUnimplementedRewardsServer()
+}
+
+func RegisterRewardsServer(s grpc.ServiceRegistrar, srv RewardsServer) {
+	s.RegisterService(&Rewards_ServiceDesc, srv)
+}
+
+func _Rewards_GetEpochRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEpochRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardsServer).GetEpochRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Rewards/GetEpochRewards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardsServer).GetEpochRewards(ctx, req.(*GetEpochRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rewards_StreamValidatorRewards_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamValidatorRewardsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RewardsServer).StreamValidatorRewards(m, &rewardsStreamValidatorRewardsServer{stream})
+}
+
+type Rewards_StreamValidatorRewardsServer interface {
+	Send(*ValidatorRewards) error
+	grpc.ServerStream
+}
+
+type rewardsStreamValidatorRewardsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rewardsStreamValidatorRewardsServer) Send(m *ValidatorRewards) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Rewards_GetAggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardsServer).GetAggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Rewards/GetAggregate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardsServer).GetAggregate(ctx, req.(*GetAggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Rewards_ServiceDesc is the grpc.ServiceDesc for Rewards service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Rewards_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "types.Rewards",
+	HandlerType: (*RewardsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEpochRewards",
+			Handler:    _Rewards_GetEpochRewards_Handler,
+		},
+		{
+			MethodName: "GetAggregate",
+			Handler:    _Rewards_GetAggregate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamValidatorRewards",
+			Handler:       _Rewards_StreamValidatorRewards_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "types/types.proto",
+}